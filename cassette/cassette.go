@@ -0,0 +1,325 @@
+// Package cassette records the calls of io streams and replays them using io2.Delegator.
+//
+// A cassette is stored as JSON lines, one Entry per line. The payloads are
+// encoded in base64.
+package cassette
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/jarxorg/io2"
+)
+
+// Op represents an operation.
+type Op string
+
+const (
+	// OpRead represents Read.
+	OpRead Op = "read"
+	// OpWrite represents Write.
+	OpWrite Op = "write"
+	// OpSeek represents Seek.
+	OpSeek Op = "seek"
+	// OpClose represents Close.
+	OpClose Op = "close"
+)
+
+// ErrMismatch is returned when a replayed call does not match the recorded call.
+var ErrMismatch = errors.New("cassette: call mismatch")
+
+// Entry is a recorded call.
+type Entry struct {
+	Op Op `json:"op"`
+	// Len is the length of the buffer passed to Read. It is informational;
+	// Player does not match it.
+	Len int `json:"len,omitempty"`
+	// Data is the bytes read by Read or passed to Write.
+	Data []byte `json:"data,omitempty"`
+	// Offset and Whence are the arguments of Seek.
+	Offset int64 `json:"offset,omitempty"`
+	Whence int   `json:"whence,omitempty"`
+	// N is the returned count of Read and Write, or the returned offset of Seek.
+	N int64 `json:"n"`
+	// Err is the returned error message.
+	Err string `json:"err,omitempty"`
+}
+
+var knownErrors = map[string]error{
+	io.EOF.Error():                io.EOF,
+	io.ErrUnexpectedEOF.Error():   io.ErrUnexpectedEOF,
+	io.ErrShortWrite.Error():      io.ErrShortWrite,
+	io.ErrClosedPipe.Error():      io.ErrClosedPipe,
+	io.ErrNoProgress.Error():      io.ErrNoProgress,
+	io2.ErrNotImplemented.Error(): io2.ErrNotImplemented,
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// Error returns the recorded error. The well-known errors of the io package
+// are returned as is, so that comparisons such as err == io.EOF work. Other
+// errors are rebuilt from their message only, so errors.Is and errors.As do
+// not match them against the original error.
+func (e *Entry) Error() error {
+	if e.Err == "" {
+		return nil
+	}
+	if err, ok := knownErrors[e.Err]; ok {
+		return err
+	}
+	return errors.New(e.Err)
+}
+
+func (e *Entry) String() string {
+	switch e.Op {
+	case OpRead:
+		return fmt.Sprintf("read(len=%d)", e.Len)
+	case OpWrite:
+		return fmt.Sprintf("write(%q)", e.Data)
+	case OpSeek:
+		return fmt.Sprintf("seek(%d, %d)", e.Offset, e.Whence)
+	}
+	return string(e.Op) + "()"
+}
+
+// Recorder writes the calls to a cassette.
+type Recorder struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+}
+
+// NewRecorder returns a Recorder that writes entries to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w)}
+}
+
+// Record returns a Delegator that calls i and records the Read, Write, Seek
+// and Close calls to w. Other methods such as WriteTo and ReadByte are served
+// by the recorded Read and Write.
+func Record(i interface{}, w io.Writer) (*io2.Delegator, *Recorder) {
+	d := &io2.Delegator{}
+	if r, ok := i.(io.Reader); ok {
+		d.ReadFunc = r.Read
+	}
+	if w, ok := i.(io.Writer); ok {
+		d.WriteFunc = w.Write
+	}
+	if s, ok := i.(io.Seeker); ok {
+		d.SeekFunc = s.Seek
+	}
+	if c, ok := i.(io.Closer); ok {
+		d.CloseFunc = c.Close
+	}
+	rec := NewRecorder(w)
+	return d.Use(rec.Middleware()), rec
+}
+
+func (rec *Recorder) record(e *Entry) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if rec.err != nil {
+		return
+	}
+	rec.err = rec.enc.Encode(e)
+}
+
+// Err returns the first error that occurred while writing the cassette.
+func (rec *Recorder) Err() error {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return rec.err
+}
+
+// Middleware returns a io2.Middleware that records Read, Write, Seek and Close.
+func (rec *Recorder) Middleware() io2.Middleware {
+	return func(next *io2.Delegator) *io2.Delegator {
+		return &io2.Delegator{
+			ReadFunc: func(p []byte) (int, error) {
+				n, err := next.Read(p)
+				rec.record(&Entry{Op: OpRead, Len: len(p), Data: p[:n], N: int64(n), Err: errorString(err)})
+				return n, err
+			},
+			WriteFunc: func(p []byte) (int, error) {
+				n, err := next.Write(p)
+				rec.record(&Entry{Op: OpWrite, Data: p, N: int64(n), Err: errorString(err)})
+				return n, err
+			},
+			SeekFunc: func(offset int64, whence int) (int64, error) {
+				n, err := next.Seek(offset, whence)
+				rec.record(&Entry{Op: OpSeek, Offset: offset, Whence: whence, N: n, Err: errorString(err)})
+				return n, err
+			},
+			CloseFunc: func() error {
+				err := next.Close()
+				rec.record(&Entry{Op: OpClose, Err: errorString(err)})
+				return err
+			},
+		}
+	}
+}
+
+// Player replays a cassette.
+type Player struct {
+	mu      sync.Mutex
+	entries []*Entry
+	pos     int
+	err     error
+	// reading is the read entry whose data is partially served, and readOff
+	// is the number of its bytes served.
+	reading *Entry
+	readOff int
+}
+
+// Load reads a cassette from r.
+func Load(r io.Reader) ([]*Entry, error) {
+	var entries []*Entry
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<30)
+	for line := 1; s.Scan(); line++ {
+		if len(s.Bytes()) == 0 {
+			continue
+		}
+		e := &Entry{}
+		if err := json.Unmarshal(s.Bytes(), e); err != nil {
+			return nil, fmt.Errorf("cassette: line %d: %w", line, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// NewPlayer returns a Player that replays the cassette read from r.
+func NewPlayer(r io.Reader) (*Player, error) {
+	entries, err := Load(r)
+	if err != nil {
+		return nil, err
+	}
+	return &Player{entries: entries}, nil
+}
+
+// Err returns the first mismatch.
+func (p *Player) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+// Done returns the first mismatch, or an error if the cassette has remaining entries.
+func (p *Player) Done() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return p.err
+	}
+	if p.reading != nil {
+		return fmt.Errorf("%w: %d bytes of call #%d remain", ErrMismatch, len(p.reading.Data)-p.readOff, p.pos-1)
+	}
+	if p.pos < len(p.entries) {
+		return fmt.Errorf("%w: %d calls remain, next %s", ErrMismatch, len(p.entries)-p.pos, p.entries[p.pos])
+	}
+	return nil
+}
+
+func (p *Player) next(call *Entry, match func(e *Entry) bool) (*Entry, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.nextLocked(call, match)
+}
+
+func (p *Player) nextLocked(call *Entry, match func(e *Entry) bool) (*Entry, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.reading != nil {
+		p.err = fmt.Errorf("%w: call #%d is %s before %d bytes of call #%d are read",
+			ErrMismatch, p.pos, call, len(p.reading.Data)-p.readOff, p.pos-1)
+		return nil, p.err
+	}
+	if p.pos >= len(p.entries) {
+		p.err = fmt.Errorf("%w: unexpected call %s after the end of cassette", ErrMismatch, call)
+		return nil, p.err
+	}
+	e := p.entries[p.pos]
+	if e.Op != call.Op || !match(e) {
+		p.err = fmt.Errorf("%w: call #%d is %s; want %s", ErrMismatch, p.pos, call, e)
+		return nil, p.err
+	}
+	p.pos++
+	return e, nil
+}
+
+// read serves the data of the next read entry into b. If b is shorter than the
+// data, the rest is served by the following reads before the next entry, and
+// the recorded error is returned with the last bytes.
+func (p *Player) read(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e := p.reading
+	if e == nil {
+		var err error
+		e, err = p.nextLocked(&Entry{Op: OpRead, Len: len(b)}, func(e *Entry) bool {
+			return true
+		})
+		if err != nil {
+			return 0, err
+		}
+		p.reading, p.readOff = e, 0
+	}
+	n := copy(b, e.Data[p.readOff:])
+	p.readOff += n
+	if p.readOff < len(e.Data) {
+		return n, nil
+	}
+	p.reading = nil
+	return n, e.Error()
+}
+
+// Delegator returns a Delegator that replays the Read, Write, Seek and Close
+// calls. The calls that differ from the cassette return ErrMismatch. Read
+// calls are matched by their order only, and the recorded bytes are served into
+// buffers of any length.
+func (p *Player) Delegator() *io2.Delegator {
+	return &io2.Delegator{
+		ReadFunc: p.read,
+		WriteFunc: func(b []byte) (int, error) {
+			e, err := p.next(&Entry{Op: OpWrite, Data: b}, func(e *Entry) bool {
+				return string(e.Data) == string(b)
+			})
+			if err != nil {
+				return 0, err
+			}
+			return int(e.N), e.Error()
+		},
+		SeekFunc: func(offset int64, whence int) (int64, error) {
+			e, err := p.next(&Entry{Op: OpSeek, Offset: offset, Whence: whence}, func(e *Entry) bool {
+				return e.Offset == offset && e.Whence == whence
+			})
+			if err != nil {
+				return 0, err
+			}
+			return e.N, e.Error()
+		},
+		CloseFunc: func() error {
+			e, err := p.next(&Entry{Op: OpClose}, func(e *Entry) bool {
+				return true
+			})
+			if err != nil {
+				return err
+			}
+			return e.Error()
+		},
+	}
+}
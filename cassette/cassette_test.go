@@ -0,0 +1,236 @@
+package cassette
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestRecordReplay(t *testing.T) {
+	run := func(rws io.ReadWriteSeeker) (string, error) {
+		if _, err := rws.Write([]byte("xyz")); err != nil {
+			return "", err
+		}
+		if _, err := rws.Seek(0, io.SeekStart); err != nil {
+			return "", err
+		}
+		p, err := ioutil.ReadAll(rws)
+		return string(p), err
+	}
+
+	buf := &bytes.Buffer{}
+	f := &file{data: []byte("abcdef")}
+	d, rec := Record(f, buf)
+	want, err := run(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if want != "xyzdef" {
+		t.Fatalf("recorded %s; want %s", want, "xyzdef")
+	}
+
+	p, err := NewPlayer(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := p.Delegator()
+	got, err := run(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("replayed %s; want %s", got, want)
+	}
+	if err := p.Done(); err == nil {
+		t.Errorf("no error for the remaining close")
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Done(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReplayMismatch(t *testing.T) {
+	cassette := `{"op":"write","data":"YWJj","n":3}
+{"op":"read","len":4,"data":"ZGVm","n":3}
+{"op":"read","len":4,"n":0,"err":"EOF"}
+{"op":"seek","offset":1,"whence":2,"n":0,"err":"custom"}
+`
+	tests := []struct {
+		call   func(d io.ReadWriteSeeker) error
+		errstr string
+	}{
+		{
+			call: func(d io.ReadWriteSeeker) error {
+				_, err := d.Write([]byte("abc"))
+				return err
+			},
+		}, {
+			call: func(d io.ReadWriteSeeker) error {
+				_, err := d.Write([]byte("abd"))
+				return err
+			},
+			errstr: `cassette: call mismatch: call #0 is write("abd"); want write("abc")`,
+		}, {
+			call: func(d io.ReadWriteSeeker) error {
+				_, err := d.Read(make([]byte, 4))
+				return err
+			},
+			errstr: `cassette: call mismatch: call #0 is read(len=4); want write("abc")`,
+		}, {
+			call: func(d io.ReadWriteSeeker) error {
+				d.Write([]byte("abc"))
+				d.Read(make([]byte, 4))
+				_, err := d.Read(make([]byte, 4))
+				if err != io.EOF {
+					return errors.New("no EOF")
+				}
+				_, err = d.Seek(1, io.SeekEnd)
+				return err
+			},
+			errstr: "custom",
+		}, {
+			call: func(d io.ReadWriteSeeker) error {
+				d.Write([]byte("abc"))
+				d.Read(make([]byte, 2))
+				_, err := d.Seek(1, io.SeekEnd)
+				return err
+			},
+			errstr: `cassette: call mismatch: call #2 is seek(1, 2) before 1 bytes of call #1 are read`,
+		},
+	}
+	for i, test := range tests {
+		p, err := NewPlayer(strings.NewReader(cassette))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = test.call(p.Delegator())
+		if test.errstr == "" {
+			if err != nil {
+				t.Errorf("tests[%d] error %v", i, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Fatalf("tests[%d] no error", i)
+		}
+		if err.Error() != test.errstr {
+			t.Errorf("tests[%d] error %s; want %s", i, err.Error(), test.errstr)
+		}
+	}
+}
+
+func TestReplayBufferSize(t *testing.T) {
+	cassette := `{"op":"read","len":512,"data":"YWJjZGVm","n":6}
+{"op":"read","len":512,"data":"Z2g=","n":2,"err":"custom"}
+`
+	tests := []struct {
+		size    int
+		want    []string
+		wantErr []string
+	}{
+		{size: 1, want: []string{"a", "b", "c", "d", "e", "f", "g", "h"}, wantErr: []string{7: "custom"}},
+		{size: 4, want: []string{"abcd", "ef", "gh"}, wantErr: []string{2: "custom"}},
+		{size: 1024, want: []string{"abcdef", "gh"}, wantErr: []string{1: "custom"}},
+	}
+	for i, test := range tests {
+		p, err := NewPlayer(strings.NewReader(cassette))
+		if err != nil {
+			t.Fatal(err)
+		}
+		d := p.Delegator()
+		for j, want := range test.want {
+			buf := make([]byte, test.size)
+			n, err := d.Read(buf)
+			if got := string(buf[:n]); got != want {
+				t.Errorf("tests[%d] read[%d] %q; want %q", i, j, got, want)
+			}
+			if got := errorString(err); got != test.wantErr[j] {
+				t.Errorf("tests[%d] read[%d] error %q; want %q", i, j, got, test.wantErr[j])
+			}
+		}
+		if err := p.Done(); err != nil {
+			t.Errorf("tests[%d] done: %v", i, err)
+		}
+	}
+
+	p, err := NewPlayer(strings.NewReader(cassette))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Delegator().Read(make([]byte, 4))
+	want := "cassette: call mismatch: 2 bytes of call #0 remain"
+	if err := p.Done(); err == nil || err.Error() != want {
+		t.Errorf("done %v; want %s", err, want)
+	}
+}
+
+func TestReplayAfterEnd(t *testing.T) {
+	p, err := NewPlayer(strings.NewReader(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = p.Delegator().Close()
+	if !errors.Is(err, ErrMismatch) {
+		t.Fatalf("error %v; want %v", err, ErrMismatch)
+	}
+	if p.Err() != err || p.Done() != err {
+		t.Errorf("the first mismatch is not kept")
+	}
+}
+
+func TestLoadError(t *testing.T) {
+	_, err := NewPlayer(strings.NewReader("{\n"))
+	if err == nil {
+		t.Fatal("no error")
+	}
+	if !strings.HasPrefix(err.Error(), "cassette: line 1:") {
+		t.Errorf("error %v", err)
+	}
+}
+
+// file is an in-memory io.ReadWriteSeeker.
+type file struct {
+	data []byte
+	off  int
+}
+
+func (f *file) Read(p []byte) (int, error) {
+	if f.off >= len(f.data) {
+		return 0, io.EOF
+	}
+	n := copy(p, f.data[f.off:])
+	f.off += n
+	return n, nil
+}
+
+func (f *file) Write(p []byte) (int, error) {
+	if grow := f.off + len(p) - len(f.data); grow > 0 {
+		f.data = append(f.data, make([]byte, grow)...)
+	}
+	n := copy(f.data[f.off:], p)
+	f.off += n
+	return n, nil
+}
+
+func (f *file) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += int64(f.off)
+	case io.SeekEnd:
+		offset += int64(len(f.data))
+	}
+	f.off = int(offset)
+	return offset, nil
+}
@@ -0,0 +1,273 @@
+package io2
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// ConcurrentWriteSeekBuffer is a paged in-memory buffer that is safe for
+// concurrent use. ReadAt and WriteAt may be called from many goroutines, and
+// writes to different pages do not block each other. Read, Write and Seek share
+// one offset and are serialized with each other.
+type ConcurrentWriteSeekBuffer struct {
+	// mu guards the page map. WriteAt and ReadAt hold the read lock while
+	// copying, and Truncate holds the write lock.
+	mu    sync.RWMutex
+	size  int
+	pages map[int]*concurrentPage
+	len   int64
+
+	// omu guards off.
+	omu sync.Mutex
+	off int64
+}
+
+type concurrentPage struct {
+	mu   sync.RWMutex
+	data []byte
+}
+
+var (
+	_ io.ReadWriteSeeker = (*ConcurrentWriteSeekBuffer)(nil)
+	_ io.ReaderAt        = (*ConcurrentWriteSeekBuffer)(nil)
+	_ io.WriterAt        = (*ConcurrentWriteSeekBuffer)(nil)
+	_ io.Closer          = (*ConcurrentWriteSeekBuffer)(nil)
+)
+
+// NewConcurrentWriteSeekBuffer returns an ConcurrentWriteSeekBuffer with the page size.
+// If pageSize is not positive, DefaultPageSize is used.
+func NewConcurrentWriteSeekBuffer(pageSize int) *ConcurrentWriteSeekBuffer {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return &ConcurrentWriteSeekBuffer{
+		size:  pageSize,
+		pages: map[int]*concurrentPage{},
+	}
+}
+
+// lockPages returns the pages for [off, off+n) with the read lock of b.mu held,
+// allocating missing pages if alloc is true. Missing pages are nil.
+func (b *ConcurrentWriteSeekBuffer) lockPages(off, n int, alloc bool) []*concurrentPage {
+	first, last := off/b.size, (off+n-1)/b.size
+	pages := make([]*concurrentPage, last-first+1)
+
+	b.mu.RLock()
+	missing := false
+	for i := range pages {
+		pages[i] = b.pages[first+i]
+		if pages[i] == nil {
+			missing = true
+		}
+	}
+	if !alloc || !missing {
+		return pages
+	}
+	b.mu.RUnlock()
+
+	b.mu.Lock()
+	for i := range pages {
+		if pages[i] = b.pages[first+i]; pages[i] == nil {
+			pages[i] = &concurrentPage{data: make([]byte, b.size)}
+			b.pages[first+i] = pages[i]
+		}
+	}
+	b.mu.Unlock()
+
+	// Truncate may drop the pages while no lock is held, so look them up again.
+	return b.lockPages(off, n, alloc)
+}
+
+// WriteAt writes the contents of p to the offset off, growing the buffer as needed.
+// WriteAt does not change the offset.
+func (b *ConcurrentWriteSeekBuffer) WriteAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	pages := b.lockPages(int(off), len(p), true)
+	defer b.mu.RUnlock()
+
+	poff := int(off) % b.size
+	rest := p
+	for _, page := range pages {
+		page.mu.Lock()
+		n := copy(page.data[poff:], rest)
+		page.mu.Unlock()
+		rest = rest[n:]
+		poff = 0
+	}
+
+	noff := off + int64(len(p))
+	for {
+		l := atomic.LoadInt64(&b.len)
+		if noff <= l || atomic.CompareAndSwapInt64(&b.len, l, noff) {
+			break
+		}
+	}
+	return len(p), nil
+}
+
+func (b *ConcurrentWriteSeekBuffer) readAt(p []byte, off int64) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if l := atomic.LoadInt64(&b.len); off < l && int64(len(p)) > l-off {
+		p = p[:l-off]
+	}
+	pages := b.lockPages(int(off), len(p), false)
+	defer b.mu.RUnlock()
+
+	// The length may have been changed before the lock is held.
+	l := atomic.LoadInt64(&b.len)
+	if off >= l {
+		return 0, io.EOF
+	}
+	n := len(p)
+	if rest := l - off; int64(n) > rest {
+		n = int(rest)
+	}
+
+	poff := int(off) % b.size
+	rest := p[:n]
+	for _, page := range pages {
+		if len(rest) == 0 {
+			break
+		}
+		var m int
+		if page == nil {
+			m = b.size - poff
+			if m > len(rest) {
+				m = len(rest)
+			}
+			zero(rest[:m])
+		} else {
+			page.mu.RLock()
+			m = copy(rest, page.data[poff:])
+			page.mu.RUnlock()
+		}
+		rest = rest[m:]
+		poff = 0
+	}
+	return n, nil
+}
+
+// ReadAt reads len(p) bytes from the offset off. ReadAt does not change the offset.
+// If ReadAt reads fewer than len(p) bytes, it returns io.EOF.
+func (b *ConcurrentWriteSeekBuffer) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+	n, err := b.readAt(p, off)
+	if err == nil && n < len(p) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// Write writes the contents of p to the offset, growing the buffer as needed.
+func (b *ConcurrentWriteSeekBuffer) Write(p []byte) (int, error) {
+	b.omu.Lock()
+	defer b.omu.Unlock()
+
+	n, err := b.WriteAt(p, b.off)
+	b.off += int64(n)
+	return n, err
+}
+
+// Read reads the next len(p) bytes from the offset or until the buffer is drained.
+// If the offset is at the end of the buffer, Read returns 0, io.EOF.
+func (b *ConcurrentWriteSeekBuffer) Read(p []byte) (int, error) {
+	b.omu.Lock()
+	defer b.omu.Unlock()
+
+	n, err := b.readAt(p, b.off)
+	b.off += int64(n)
+	return n, err
+}
+
+// Seek sets the offset for the next Read or Write to offset, interpreted according to whence.
+func (b *ConcurrentWriteSeekBuffer) Seek(offset int64, whence int) (int64, error) {
+	b.omu.Lock()
+	defer b.omu.Unlock()
+
+	noff := int64(0)
+	switch whence {
+	case io.SeekStart:
+		noff = offset
+	case io.SeekCurrent:
+		noff = b.off + offset
+	case io.SeekEnd:
+		noff = int64(b.Len()) + offset
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if noff < 0 {
+		return 0, errors.New("negative position")
+	}
+	b.off = noff
+	return noff, nil
+}
+
+// Close calls b.Truncate(0).
+func (b *ConcurrentWriteSeekBuffer) Close() error {
+	b.Truncate(0)
+	return nil
+}
+
+// Offset returns the offset.
+func (b *ConcurrentWriteSeekBuffer) Offset() int {
+	b.omu.Lock()
+	defer b.omu.Unlock()
+
+	return int(b.off)
+}
+
+// Len returns the number of bytes of the buffer.
+func (b *ConcurrentWriteSeekBuffer) Len() int {
+	return int(atomic.LoadInt64(&b.len))
+}
+
+// Bytes returns a copy of the contents of the buffer. Writes that run
+// concurrently with Bytes may or may not be included.
+func (b *ConcurrentWriteSeekBuffer) Bytes() []byte {
+	b.mu.RLock()
+	p := make([]byte, atomic.LoadInt64(&b.len))
+	b.mu.RUnlock()
+
+	n, _ := b.readAt(p, 0)
+	return p[:n]
+}
+
+// Truncate changes the size of the buffer with offset. If n is negative, it is
+// relative to the offset. If n is greater than the length, the buffer is
+// extended with zeros like WriteSeekBuffer.Truncate.
+func (b *ConcurrentWriteSeekBuffer) Truncate(n int) {
+	b.omu.Lock()
+	defer b.omu.Unlock()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if n < 0 {
+		n = int(b.off) + n
+	}
+	if n < 0 {
+		n = 0
+	}
+	if int64(n) < b.len {
+		last := (n + b.size - 1) / b.size
+		for i, page := range b.pages {
+			if i >= last {
+				delete(b.pages, i)
+			} else if i == n/b.size {
+				zero(page.data[n%b.size:])
+			}
+		}
+	}
+	atomic.StoreInt64(&b.len, int64(n))
+	b.off = int64(n)
+}
@@ -0,0 +1,124 @@
+package io2
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/jarxorg/io2/io2test"
+)
+
+func TestConcurrentWriteSeekBuffer(t *testing.T) {
+	b := NewConcurrentWriteSeekBuffer(4)
+	defer b.Close()
+
+	b.Write([]byte(`123456789`))
+	b.Truncate(5)
+	b.Seek(2, io.SeekCurrent)
+	b.Write([]byte(`x`))
+
+	want := []byte{'1', '2', '3', '4', '5', 0, 0, 'x'}
+	if got := b.Bytes(); !bytes.Equal(got, want) {
+		t.Errorf("bytes %v; want %v", got, want)
+	}
+	if b.Len() != 8 || b.Offset() != 8 {
+		t.Errorf("len %d off %d; want %d %d", b.Len(), b.Offset(), 8, 8)
+	}
+	b.Truncate(3)
+	b.Truncate(6)
+	if got, want := b.Bytes(), []byte{'1', '2', '3', 0, 0, 0}; !bytes.Equal(got, want) {
+		t.Errorf("extended bytes %v; want %v", got, want)
+	}
+	if b.Len() != 6 || b.Offset() != 6 {
+		t.Errorf("extended len %d off %d; want %d %d", b.Len(), b.Offset(), 6, 6)
+	}
+	if _, err := b.WriteAt(nil, -1); err == nil {
+		t.Errorf("write at negative offset: no error")
+	}
+	if _, err := b.ReadAt(nil, -1); err == nil {
+		t.Errorf("read at negative offset: no error")
+	}
+}
+
+func TestConcurrentWriteSeekBuffer_Stress(t *testing.T) {
+	const (
+		workers = 16
+		region  = 1000
+		rounds  = 20
+	)
+	b := NewConcurrentWriteSeekBuffer(64)
+	defer b.Close()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			data := bytes.Repeat([]byte{byte('a' + w)}, region)
+			p := make([]byte, region)
+			off := int64(w * region)
+			for r := 0; r < rounds; r++ {
+				// Write in pieces so that pages are shared between workers.
+				for i := 0; i < region; i += 100 {
+					if _, err := b.WriteAt(data[i:i+100], off+int64(i)); err != nil {
+						t.Error(err)
+						return
+					}
+				}
+				if _, err := b.ReadAt(p, off); err != nil && err != io.EOF {
+					t.Error(err)
+					return
+				}
+				b.Len()
+				b.Bytes()
+			}
+		}(w)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// Grow and shrink the buffer past the regions of the workers.
+		for r := 0; r < rounds; r++ {
+			b.Seek(workers*region, io.SeekStart)
+			b.Write([]byte(`!`))
+			b.Truncate(workers * region)
+		}
+	}()
+	wg.Wait()
+
+	got := b.Bytes()
+	if len(got) != workers*region {
+		t.Fatalf("len %d; want %d", len(got), workers*region)
+	}
+	for w := 0; w < workers; w++ {
+		want := bytes.Repeat([]byte{byte('a' + w)}, region)
+		if !bytes.Equal(got[w*region:(w+1)*region], want) {
+			t.Errorf("region[%d] is broken", w)
+		}
+	}
+}
+
+func TestConcurrentWriteSeekBuffer_Contract(t *testing.T) {
+	content := []byte("Hello, World!")
+	newFilled := func() *ConcurrentWriteSeekBuffer {
+		b := NewConcurrentWriteSeekBuffer(4)
+		b.Write(content)
+		b.Seek(0, io.SeekStart)
+		return b
+	}
+	io2test.TestWriter(t, func(t *testing.T) io.Writer {
+		return NewConcurrentWriteSeekBuffer(4)
+	}, func(t *testing.T, w io.Writer) []byte {
+		return w.(*ConcurrentWriteSeekBuffer).Bytes()
+	})
+	io2test.TestWriterAt(t, func(t *testing.T) io.WriterAt {
+		return NewConcurrentWriteSeekBuffer(4)
+	}, func(t *testing.T, w io.WriterAt) []byte {
+		return w.(*ConcurrentWriteSeekBuffer).Bytes()
+	})
+	io2test.TestSeeker(t, func(t *testing.T) io.Seeker { return newFilled() }, content)
+	io2test.TestReader(t, func(t *testing.T) io.Reader { return newFilled() }, content)
+	io2test.TestReaderAt(t, func(t *testing.T) io.ReaderAt { return newFilled() }, content)
+	io2test.TestCloser(t, func(t *testing.T) io.Closer { return newFilled() }, true)
+}
@@ -2,23 +2,55 @@ package io2
 
 import (
 	"io"
+	"unicode/utf8"
 )
 
-// Delegator implements Reader, Writer, Seeker, Closer.
+// Delegator implements Reader, Writer, Seeker, Closer, ReaderAt, WriterAt,
+// ReaderFrom, WriterTo, ByteScanner, ByteWriter, RuneScanner and StringWriter.
 type Delegator struct {
-	ReadFunc  func(p []byte) (n int, err error)
-	WriteFunc func(p []byte) (n int, err error)
-	SeekFunc  func(offset int64, whence int) (int64, error)
-	CloseFunc func() error
+	ReadFunc        func(p []byte) (n int, err error)
+	WriteFunc       func(p []byte) (n int, err error)
+	SeekFunc        func(offset int64, whence int) (int64, error)
+	CloseFunc       func() error
+	ReadAtFunc      func(p []byte, off int64) (n int, err error)
+	WriteAtFunc     func(p []byte, off int64) (n int, err error)
+	ReadFromFunc    func(r io.Reader) (n int64, err error)
+	WriteToFunc     func(w io.Writer) (n int64, err error)
+	ReadByteFunc    func() (byte, error)
+	UnreadByteFunc  func() error
+	WriteByteFunc   func(c byte) error
+	ReadRuneFunc    func() (r rune, size int, err error)
+	UnreadRuneFunc  func() error
+	WriteStringFunc func(s string) (n int, err error)
 }
 
 var (
-	_ io.Reader = (*Delegator)(nil)
-	_ io.Writer = (*Delegator)(nil)
-	_ io.Seeker = (*Delegator)(nil)
-	_ io.Closer = (*Delegator)(nil)
+	_ io.Reader       = (*Delegator)(nil)
+	_ io.Writer       = (*Delegator)(nil)
+	_ io.Seeker       = (*Delegator)(nil)
+	_ io.Closer       = (*Delegator)(nil)
+	_ io.ReaderAt     = (*Delegator)(nil)
+	_ io.WriterAt     = (*Delegator)(nil)
+	_ io.ReaderFrom   = (*Delegator)(nil)
+	_ io.WriterTo     = (*Delegator)(nil)
+	_ io.ByteScanner  = (*Delegator)(nil)
+	_ io.ByteWriter   = (*Delegator)(nil)
+	_ io.RuneScanner  = (*Delegator)(nil)
+	_ io.StringWriter = (*Delegator)(nil)
 )
 
+type readerFunc func(p []byte) (int, error)
+
+func (f readerFunc) Read(p []byte) (int, error) {
+	return f(p)
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
+
 // Read calls ReadFunc(p).
 func (d *Delegator) Read(p []byte) (int, error) {
 	if d.ReadFunc == nil {
@@ -52,7 +84,154 @@ func (d *Delegator) Close() error {
 	return d.CloseFunc()
 }
 
-// Delegate returns a Delegator with the provided io interfaces (io.Reader, io.Seeker, io.Writer, io.Closer).
+// ReadAt calls ReadAtFunc(p, off).
+func (d *Delegator) ReadAt(p []byte, off int64) (int, error) {
+	if d.ReadAtFunc == nil {
+		return 0, ErrNotImplemented
+	}
+	return d.ReadAtFunc(p, off)
+}
+
+// WriteAt calls WriteAtFunc(p, off).
+func (d *Delegator) WriteAt(p []byte, off int64) (int, error) {
+	if d.WriteAtFunc == nil {
+		return 0, ErrNotImplemented
+	}
+	return d.WriteAtFunc(p, off)
+}
+
+// ReadFrom calls ReadFromFunc(r).
+// If ReadFromFunc is nil and WriteFunc is not nil, ReadFrom copies r using Write.
+func (d *Delegator) ReadFrom(r io.Reader) (int64, error) {
+	if d.ReadFromFunc == nil {
+		if d.WriteFunc == nil {
+			return 0, ErrNotImplemented
+		}
+		return io.Copy(writerFunc(d.Write), r)
+	}
+	return d.ReadFromFunc(r)
+}
+
+// WriteTo calls WriteToFunc(w).
+// If WriteToFunc is nil and ReadFunc is not nil, WriteTo copies to w using Read.
+func (d *Delegator) WriteTo(w io.Writer) (int64, error) {
+	if d.WriteToFunc == nil {
+		if d.ReadFunc == nil {
+			return 0, ErrNotImplemented
+		}
+		return io.Copy(w, readerFunc(d.Read))
+	}
+	return d.WriteToFunc(w)
+}
+
+// ReadByte calls ReadByteFunc().
+// If ReadByteFunc is nil and ReadFunc is not nil, ReadByte reads a byte using Read.
+func (d *Delegator) ReadByte() (byte, error) {
+	if d.ReadByteFunc == nil {
+		if d.ReadFunc == nil {
+			return 0, ErrNotImplemented
+		}
+		var p [1]byte
+		if _, err := io.ReadFull(readerFunc(d.Read), p[:]); err != nil {
+			return 0, err
+		}
+		return p[0], nil
+	}
+	return d.ReadByteFunc()
+}
+
+// UnreadByte calls UnreadByteFunc().
+func (d *Delegator) UnreadByte() error {
+	if d.UnreadByteFunc == nil {
+		return ErrNotImplemented
+	}
+	return d.UnreadByteFunc()
+}
+
+// WriteByte calls WriteByteFunc(c).
+// If WriteByteFunc is nil and WriteFunc is not nil, WriteByte writes c using Write.
+func (d *Delegator) WriteByte(c byte) error {
+	if d.WriteByteFunc == nil {
+		if d.WriteFunc == nil {
+			return ErrNotImplemented
+		}
+		_, err := d.Write([]byte{c})
+		return err
+	}
+	return d.WriteByteFunc(c)
+}
+
+// ReadRune calls ReadRuneFunc().
+// If ReadRuneFunc is nil and ReadFunc is not nil, ReadRune reads a UTF-8
+// encoded rune byte by byte using Read, and pushes back the byte that cannot
+// continue an invalid sequence using UnreadByte, or Seek if UnreadByteFunc is
+// nil. ReadRune returns ErrNotImplemented if neither can push back a byte.
+func (d *Delegator) ReadRune() (rune, int, error) {
+	if d.ReadRuneFunc == nil {
+		if d.ReadFunc == nil || (d.UnreadByteFunc == nil && d.SeekFunc == nil) {
+			return 0, 0, ErrNotImplemented
+		}
+		return d.readRune()
+	}
+	return d.ReadRuneFunc()
+}
+
+func (d *Delegator) readRune() (rune, int, error) {
+	var p [utf8.UTFMax]byte
+	n := 0
+	for n < len(p) && !utf8.FullRune(p[:n]) {
+		c, err := d.ReadByte()
+		if err != nil {
+			if n == 0 {
+				return 0, 0, err
+			}
+			// A truncated sequence.
+			return utf8.RuneError, n, nil
+		}
+		p[n] = c
+		n++
+	}
+	r, size := utf8.DecodeRune(p[:n])
+	if size < n {
+		// The last byte cannot continue the sequence, so it is pushed back.
+		if err := d.unreadByte(); err != nil {
+			return 0, 0, err
+		}
+		return utf8.RuneError, n - 1, nil
+	}
+	return r, size, nil
+}
+
+func (d *Delegator) unreadByte() error {
+	if d.UnreadByteFunc != nil {
+		return d.UnreadByteFunc()
+	}
+	_, err := d.SeekFunc(-1, io.SeekCurrent)
+	return err
+}
+
+// UnreadRune calls UnreadRuneFunc().
+func (d *Delegator) UnreadRune() error {
+	if d.UnreadRuneFunc == nil {
+		return ErrNotImplemented
+	}
+	return d.UnreadRuneFunc()
+}
+
+// WriteString calls WriteStringFunc(s).
+// If WriteStringFunc is nil and WriteFunc is not nil, WriteString writes s using Write.
+func (d *Delegator) WriteString(s string) (int, error) {
+	if d.WriteStringFunc == nil {
+		if d.WriteFunc == nil {
+			return 0, ErrNotImplemented
+		}
+		return d.Write([]byte(s))
+	}
+	return d.WriteStringFunc(s)
+}
+
+// Delegate returns a Delegator with the provided io interfaces (io.Reader, io.Seeker, io.Writer, io.Closer,
+// io.ReaderAt, io.WriterAt, io.ReaderFrom, io.WriterTo, io.ByteScanner, io.ByteWriter, io.RuneScanner, io.StringWriter).
 func Delegate(i interface{}) *Delegator {
 	d := &Delegator{}
 	if r, ok := i.(io.Reader); ok {
@@ -67,9 +246,66 @@ func Delegate(i interface{}) *Delegator {
 	if c, ok := i.(io.Closer); ok {
 		d.CloseFunc = c.Close
 	}
+	if r, ok := i.(io.ReaderAt); ok {
+		d.ReadAtFunc = r.ReadAt
+	}
+	if w, ok := i.(io.WriterAt); ok {
+		d.WriteAtFunc = w.WriteAt
+	}
+	if r, ok := i.(io.ReaderFrom); ok {
+		d.ReadFromFunc = r.ReadFrom
+	}
+	if w, ok := i.(io.WriterTo); ok {
+		d.WriteToFunc = w.WriteTo
+	}
+	if r, ok := i.(io.ByteReader); ok {
+		d.ReadByteFunc = r.ReadByte
+	}
+	if s, ok := i.(io.ByteScanner); ok {
+		d.UnreadByteFunc = s.UnreadByte
+	}
+	if w, ok := i.(io.ByteWriter); ok {
+		d.WriteByteFunc = w.WriteByte
+	}
+	if r, ok := i.(io.RuneReader); ok {
+		d.ReadRuneFunc = r.ReadRune
+	}
+	if s, ok := i.(io.RuneScanner); ok {
+		d.UnreadRuneFunc = s.UnreadRune
+	}
+	if w, ok := i.(io.StringWriter); ok {
+		d.WriteStringFunc = w.WriteString
+	}
 	return d
 }
 
+//go:generate go run gen_exact.go
+
+// DelegatorUnwrapper is implemented by the values returned by WrapDelegator and DelegateIO.
+type DelegatorUnwrapper interface {
+	// UnwrapDelegator returns the underlying Delegator.
+	UnwrapDelegator() *Delegator
+}
+
+type delegatorUnwrapper struct {
+	d *Delegator
+}
+
+func (u delegatorUnwrapper) UnwrapDelegator() *Delegator {
+	return u.d
+}
+
+// DelegateIO returns a value that delegates to i and implements the stream interfaces
+// (io.Reader, io.Writer, io.Seeker, io.Closer, io.ReaderAt, io.WriterAt, io.ReaderFrom, io.WriterTo)
+// only if i implements them. Unlike Delegate, type assertions to these interfaces on the returned
+// value behave as on i. The returned value does not implement the byte, rune and string interfaces
+// (io.ByteReader, io.ByteScanner, io.ByteWriter, io.RuneReader, io.RuneScanner, io.StringWriter)
+// at all, so its method set is not the same as that of i.
+// The Delegator can be retrieved by the returned value's UnwrapDelegator method.
+func DelegateIO(i interface{}) interface{} {
+	return WrapDelegator(Delegate(i), i)
+}
+
 // DelegateReader returns a Delegator with the provided Read function.
 func DelegateReader(i io.Reader) *Delegator {
 	return &Delegator{
@@ -160,6 +396,78 @@ func DelegateWriteSeekCloser(i WriteSeekCloser) *Delegator {
 	}
 }
 
+// DelegateReaderAt returns a Delegator with the provided ReadAt function.
+func DelegateReaderAt(i io.ReaderAt) *Delegator {
+	return &Delegator{
+		ReadAtFunc: i.ReadAt,
+	}
+}
+
+// DelegateWriterAt returns a Delegator with the provided WriteAt function.
+func DelegateWriterAt(i io.WriterAt) *Delegator {
+	return &Delegator{
+		WriteAtFunc: i.WriteAt,
+	}
+}
+
+// DelegateReaderFrom returns a Delegator with the provided ReadFrom function.
+func DelegateReaderFrom(i io.ReaderFrom) *Delegator {
+	return &Delegator{
+		ReadFromFunc: i.ReadFrom,
+	}
+}
+
+// DelegateWriterTo returns a Delegator with the provided WriteTo function.
+func DelegateWriterTo(i io.WriterTo) *Delegator {
+	return &Delegator{
+		WriteToFunc: i.WriteTo,
+	}
+}
+
+// DelegateByteReader returns a Delegator with the provided ReadByte function.
+func DelegateByteReader(i io.ByteReader) *Delegator {
+	return &Delegator{
+		ReadByteFunc: i.ReadByte,
+	}
+}
+
+// DelegateByteScanner returns a Delegator with the provided ReadByte and UnreadByte functions.
+func DelegateByteScanner(i io.ByteScanner) *Delegator {
+	return &Delegator{
+		ReadByteFunc:   i.ReadByte,
+		UnreadByteFunc: i.UnreadByte,
+	}
+}
+
+// DelegateByteWriter returns a Delegator with the provided WriteByte function.
+func DelegateByteWriter(i io.ByteWriter) *Delegator {
+	return &Delegator{
+		WriteByteFunc: i.WriteByte,
+	}
+}
+
+// DelegateRuneReader returns a Delegator with the provided ReadRune function.
+func DelegateRuneReader(i io.RuneReader) *Delegator {
+	return &Delegator{
+		ReadRuneFunc: i.ReadRune,
+	}
+}
+
+// DelegateRuneScanner returns a Delegator with the provided ReadRune and UnreadRune functions.
+func DelegateRuneScanner(i io.RuneScanner) *Delegator {
+	return &Delegator{
+		ReadRuneFunc:   i.ReadRune,
+		UnreadRuneFunc: i.UnreadRune,
+	}
+}
+
+// DelegateStringWriter returns a Delegator with the provided WriteString function.
+func DelegateStringWriter(i io.StringWriter) *Delegator {
+	return &Delegator{
+		WriteStringFunc: i.WriteString,
+	}
+}
+
 // NopReadCloser returns a ReadCloser with a no-op Close method wrapping the provided interface.
 // This function like io.NopCloser(io.Reader).
 func NopReadCloser(r io.Reader) io.ReadCloser {
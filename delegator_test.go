@@ -1,8 +1,12 @@
 package io2
 
 import (
+	"bytes"
 	"errors"
 	"io"
+	"io/ioutil"
+	"os"
+	"strings"
 	"testing"
 )
 
@@ -20,6 +24,36 @@ func testDelegatorErrors(t *testing.T, d *Delegator, wantErr error) {
 	if err = d.Close(); err != nil {
 		t.Errorf("unknown: %v", err)
 	}
+	if _, err = d.ReadAt([]byte{}, 0); !errors.Is(err, wantErr) {
+		t.Errorf("unknown: %v", err)
+	}
+	if _, err = d.WriteAt([]byte{}, 0); !errors.Is(err, wantErr) {
+		t.Errorf("unknown: %v", err)
+	}
+	if _, err = d.ReadFrom(strings.NewReader("")); !errors.Is(err, wantErr) {
+		t.Errorf("unknown: %v", err)
+	}
+	if _, err = d.WriteTo(ioutil.Discard); !errors.Is(err, wantErr) {
+		t.Errorf("unknown: %v", err)
+	}
+	if _, err = d.ReadByte(); !errors.Is(err, wantErr) {
+		t.Errorf("unknown: %v", err)
+	}
+	if err = d.UnreadByte(); !errors.Is(err, wantErr) {
+		t.Errorf("unknown: %v", err)
+	}
+	if err = d.WriteByte(0); !errors.Is(err, wantErr) {
+		t.Errorf("unknown: %v", err)
+	}
+	if _, _, err = d.ReadRune(); !errors.Is(err, wantErr) {
+		t.Errorf("unknown: %v", err)
+	}
+	if err = d.UnreadRune(); !errors.Is(err, wantErr) {
+		t.Errorf("unknown: %v", err)
+	}
+	if _, err = d.WriteString(""); !errors.Is(err, wantErr) {
+		t.Errorf("unknown: %v", err)
+	}
 }
 
 func TestDelegator_ErrNotImplemented(t *testing.T) {
@@ -41,11 +75,106 @@ func TestDelegator(t *testing.T) {
 		CloseFunc: func() error {
 			return nil
 		},
+		ReadAtFunc: func(_ []byte, _ int64) (int, error) {
+			return 0, wantErr
+		},
+		WriteAtFunc: func(_ []byte, _ int64) (int, error) {
+			return 0, wantErr
+		},
+		ReadFromFunc: func(_ io.Reader) (int64, error) {
+			return 0, wantErr
+		},
+		WriteToFunc: func(_ io.Writer) (int64, error) {
+			return 0, wantErr
+		},
+		ReadByteFunc: func() (byte, error) {
+			return 0, wantErr
+		},
+		UnreadByteFunc: func() error {
+			return wantErr
+		},
+		WriteByteFunc: func(_ byte) error {
+			return wantErr
+		},
+		ReadRuneFunc: func() (rune, int, error) {
+			return 0, 0, wantErr
+		},
+		UnreadRuneFunc: func() error {
+			return wantErr
+		},
+		WriteStringFunc: func(_ string) (int, error) {
+			return 0, wantErr
+		},
 	}
 	testDelegatorErrors(t, d, wantErr)
 	testDelegatorErrors(t, Delegate(d), wantErr)
 }
 
+func TestDelegator_Fallbacks(t *testing.T) {
+	r := DelegateReadSeeker(strings.NewReader("a\u3042b\xff\xe4\xb8A\xe4"))
+
+	c, err := r.ReadByte()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c != 'a' {
+		t.Errorf("ReadByte %q; want %q", c, 'a')
+	}
+	runes := []struct {
+		r    rune
+		size int
+	}{
+		{r: '\u3042', size: 3},
+		{r: 'b', size: 1},
+		{r: '\uFFFD', size: 1},
+		{r: '\uFFFD', size: 2},
+		{r: 'A', size: 1},
+		{r: '\uFFFD', size: 1},
+	}
+	for i, want := range runes {
+		got, size, err := r.ReadRune()
+		if err != nil {
+			t.Fatalf("runes[%d] %v", i, err)
+		}
+		if got != want.r || size != want.size {
+			t.Errorf("runes[%d] ReadRune %q %d; want %q %d", i, got, size, want.r, want.size)
+		}
+	}
+	if _, _, err = r.ReadRune(); err != io.EOF {
+		t.Errorf("ReadRune error %v; want %v", err, io.EOF)
+	}
+
+	src := bytes.NewBufferString("\xe4\xb8A")
+	br := &Delegator{ReadFunc: src.Read, UnreadByteFunc: src.UnreadByte}
+	if got, size, err := br.ReadRune(); err != nil || got != '\uFFFD' || size != 2 {
+		t.Errorf("ReadRune %q %d %v; want %q %d", got, size, err, '\uFFFD', 2)
+	}
+	if got, size, err := br.ReadRune(); err != nil || got != 'A' || size != 1 {
+		t.Errorf("ReadRune %q %d %v; want %q %d", got, size, err, 'A', 1)
+	}
+	if _, _, err = DelegateReader(strings.NewReader("a")).ReadRune(); err != ErrNotImplemented {
+		t.Errorf("ReadRune error %v; want %v", err, ErrNotImplemented)
+	}
+
+	buf := &bytes.Buffer{}
+	w := DelegateWriter(struct{ io.Writer }{buf})
+	if err = w.WriteByte('a'); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.WriteString("bc"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.ReadFrom(strings.NewReader("def")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = DelegateReader(strings.NewReader("ghi")).WriteTo(w); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "abcdefghi"; got != want {
+		t.Errorf("got %s; want %s", got, want)
+	}
+}
+
 func TestDelegates(t *testing.T) {
 	d := &Delegator{}
 	var (
@@ -60,6 +189,16 @@ func TestDelegates(t *testing.T) {
 		_ io.WriteCloser     = DelegateWriteCloser(d)
 		_ io.WriteSeeker     = DelegateWriteSeeker(d)
 		_ WriteSeekCloser    = DelegateWriteSeekCloser(d)
+		_ io.ReaderAt        = DelegateReaderAt(d)
+		_ io.WriterAt        = DelegateWriterAt(d)
+		_ io.ReaderFrom      = DelegateReaderFrom(d)
+		_ io.WriterTo        = DelegateWriterTo(d)
+		_ io.ByteReader      = DelegateByteReader(d)
+		_ io.ByteScanner     = DelegateByteScanner(d)
+		_ io.ByteWriter      = DelegateByteWriter(d)
+		_ io.RuneReader      = DelegateRuneReader(d)
+		_ io.RuneScanner     = DelegateRuneScanner(d)
+		_ io.StringWriter    = DelegateStringWriter(d)
 	)
 }
 
@@ -72,3 +211,99 @@ func TestNops(t *testing.T) {
 		_ io.WriteCloser     = NopWriteCloser(d)
 	)
 }
+
+func TestDelegateIO(t *testing.T) {
+	interfaces := []struct {
+		name   string
+		assert func(v interface{}) bool
+	}{
+		{name: "Reader", assert: func(v interface{}) bool { _, ok := v.(io.Reader); return ok }},
+		{name: "Writer", assert: func(v interface{}) bool { _, ok := v.(io.Writer); return ok }},
+		{name: "Seeker", assert: func(v interface{}) bool { _, ok := v.(io.Seeker); return ok }},
+		{name: "Closer", assert: func(v interface{}) bool { _, ok := v.(io.Closer); return ok }},
+		{name: "ReaderAt", assert: func(v interface{}) bool { _, ok := v.(io.ReaderAt); return ok }},
+		{name: "WriterAt", assert: func(v interface{}) bool { _, ok := v.(io.WriterAt); return ok }},
+		{name: "ReaderFrom", assert: func(v interface{}) bool { _, ok := v.(io.ReaderFrom); return ok }},
+		{name: "WriterTo", assert: func(v interface{}) bool { _, ok := v.(io.WriterTo); return ok }},
+	}
+	f, err := ioutil.TempFile("", "*.exact")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	tests := []interface{}{
+		struct{}{},
+		struct{ io.Reader }{strings.NewReader("")},
+		struct{ io.Writer }{&bytes.Buffer{}},
+		struct{ io.ReadCloser }{NopReadCloser(strings.NewReader(""))},
+		struct{ io.WriteCloser }{NopWriteCloser(&bytes.Buffer{})},
+		strings.NewReader(""),
+		bytes.NewBuffer(nil),
+		NewWriteSeekBuffer(0),
+		f,
+		&Delegator{},
+	}
+	for i, test := range tests {
+		v := DelegateIO(test)
+		for _, in := range interfaces {
+			if got, want := in.assert(v), in.assert(test); got != want {
+				t.Errorf("tests[%d] %s is %v; want %v", i, in.name, got, want)
+			}
+		}
+		u, ok := v.(DelegatorUnwrapper)
+		if !ok {
+			t.Fatalf("tests[%d] no DelegatorUnwrapper", i)
+		}
+		if u.UnwrapDelegator() == nil {
+			t.Errorf("tests[%d] no delegator", i)
+		}
+	}
+}
+
+func TestDelegateIO_Reader(t *testing.T) {
+	v := DelegateIO(struct{ io.Reader }{strings.NewReader("abc")})
+	if _, ok := v.(io.Seeker); ok {
+		t.Errorf("unexpected io.Seeker")
+	}
+	if _, ok := v.(io.WriterTo); ok {
+		t.Errorf("unexpected io.WriterTo")
+	}
+	d := v.(DelegatorUnwrapper).UnwrapDelegator()
+	org := d.ReadFunc
+	d.ReadFunc = func(p []byte) (int, error) {
+		n, err := org(p)
+		copy(p, bytes.ToUpper(p[:n]))
+		return n, err
+	}
+	got, err := ioutil.ReadAll(v.(io.Reader))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "ABC" {
+		t.Errorf("got %s; want %s", got, "ABC")
+	}
+}
+
+func TestDelegateIO_NotPreserved(t *testing.T) {
+	tests := []interface{}{
+		strings.NewReader(""),
+		bytes.NewBuffer(nil),
+	}
+	for i, test := range tests {
+		v := DelegateIO(test)
+		if _, ok := v.(io.ByteReader); ok {
+			t.Errorf("tests[%d] unexpected io.ByteReader", i)
+		}
+		if _, ok := v.(io.ByteWriter); ok {
+			t.Errorf("tests[%d] unexpected io.ByteWriter", i)
+		}
+		if _, ok := v.(io.RuneReader); ok {
+			t.Errorf("tests[%d] unexpected io.RuneReader", i)
+		}
+		if _, ok := v.(io.StringWriter); ok {
+			t.Errorf("tests[%d] unexpected io.StringWriter", i)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package io2
+
+import (
+	"io"
+	"sort"
+)
+
+// Extent is a range of bytes [Off, Off+Len).
+type Extent struct {
+	Off int
+	Len int
+}
+
+// End returns the offset just past the extent.
+func (e Extent) End() int {
+	return e.Off + e.Len
+}
+
+// addExtent adds e to the sorted extents, merging overlapping and adjacent ones.
+func addExtent(extents []Extent, e Extent) []Extent {
+	if e.Len <= 0 {
+		return extents
+	}
+	// i is the first extent that ends at or after e.Off; j is the first extent
+	// that starts after e.End().
+	i := sort.Search(len(extents), func(k int) bool { return extents[k].End() >= e.Off })
+	j := sort.Search(len(extents), func(k int) bool { return extents[k].Off > e.End() })
+	if i == j {
+		extents = append(extents, Extent{})
+		copy(extents[i+1:], extents[i:])
+		extents[i] = e
+		return extents
+	}
+	if extents[i].Off < e.Off {
+		e.Len += e.Off - extents[i].Off
+		e.Off = extents[i].Off
+	}
+	if end := extents[j-1].End(); end > e.End() {
+		e.Len = end - e.Off
+	}
+	extents[i] = e
+	return append(extents[:i+1], extents[j:]...)
+}
+
+// clipExtents removes the bytes at or after n from the sorted extents.
+func clipExtents(extents []Extent, n int) []Extent {
+	i := sort.Search(len(extents), func(k int) bool { return extents[k].End() > n })
+	if i < len(extents) && extents[i].Off < n {
+		extents[i].Len = n - extents[i].Off
+		i++
+	}
+	return extents[:i]
+}
+
+// dirtyState tracks the changes to a WriteSeekBuffer since the last Flush.
+type dirtyState struct {
+	extents []Extent
+	// truncLen is the smallest length set by Truncate if truncated.
+	truncated bool
+	truncLen  int
+}
+
+func (d *dirtyState) write(off, n int) {
+	d.extents = addExtent(d.extents, Extent{Off: off, Len: n})
+}
+
+func (d *dirtyState) truncate(n int) {
+	d.extents = clipExtents(d.extents, n)
+	if !d.truncated || n < d.truncLen {
+		d.truncated = true
+		d.truncLen = n
+	}
+}
+
+func (d *dirtyState) reset() {
+	d.extents = nil
+	d.truncated = false
+}
+
+// DirtyExtents returns the sorted extents written since the last Flush.
+// Overlapping and adjacent writes are merged into one extent.
+func (b *WriteSeekBuffer) DirtyExtents() []Extent {
+	return append([]Extent{}, b.dirty.extents...)
+}
+
+// Truncated reports whether Truncate reduced the length since the last Flush.
+func (b *WriteSeekBuffer) Truncated() bool {
+	return b.dirty.truncated
+}
+
+// Flush writes the dirty extents to w and marks them clean. If the length was
+// reduced by Truncate, Flush first calls Truncate of w with the smallest length
+// if w implements it like *os.File; otherwise the length change stays dirty.
+// If an error occurs, the extents that have not been written stay dirty.
+func (b *WriteSeekBuffer) Flush(w io.WriterAt) error {
+	if b.dirty.truncated {
+		if t, ok := w.(interface{ Truncate(size int64) error }); ok {
+			if err := t.Truncate(int64(b.dirty.truncLen)); err != nil {
+				return err
+			}
+			b.dirty.truncated = false
+		}
+	}
+	for len(b.dirty.extents) > 0 {
+		e := b.dirty.extents[0]
+		p := make([]byte, e.Len)
+		if _, err := b.readAt(p, e.Off); err != nil {
+			return err
+		}
+		if _, err := w.WriteAt(p, int64(e.Off)); err != nil {
+			return err
+		}
+		b.dirty.extents = b.dirty.extents[1:]
+	}
+	b.dirty.extents = nil
+	return nil
+}
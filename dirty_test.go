@@ -0,0 +1,172 @@
+package io2
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAddExtent(t *testing.T) {
+	tests := []struct {
+		extents []Extent
+		e       Extent
+		want    []Extent
+	}{
+		{
+			e:    Extent{Off: 2, Len: 3},
+			want: []Extent{{2, 3}},
+		}, {
+			extents: []Extent{{2, 3}},
+			e:       Extent{Off: 0, Len: 0},
+			want:    []Extent{{2, 3}},
+		}, {
+			extents: []Extent{{2, 3}},
+			e:       Extent{Off: 10, Len: 1},
+			want:    []Extent{{2, 3}, {10, 1}},
+		}, {
+			extents: []Extent{{2, 3}, {10, 1}},
+			e:       Extent{Off: 0, Len: 1},
+			want:    []Extent{{0, 1}, {2, 3}, {10, 1}},
+		}, {
+			extents: []Extent{{2, 3}, {10, 1}},
+			e:       Extent{Off: 5, Len: 2},
+			want:    []Extent{{2, 5}, {10, 1}},
+		}, {
+			extents: []Extent{{2, 3}, {10, 1}},
+			e:       Extent{Off: 7, Len: 3},
+			want:    []Extent{{2, 3}, {7, 4}},
+		}, {
+			extents: []Extent{{2, 3}, {10, 1}, {20, 5}},
+			e:       Extent{Off: 3, Len: 10},
+			want:    []Extent{{2, 11}, {20, 5}},
+		}, {
+			extents: []Extent{{2, 3}, {10, 1}, {20, 5}},
+			e:       Extent{Off: 0, Len: 30},
+			want:    []Extent{{0, 30}},
+		}, {
+			extents: []Extent{{2, 10}},
+			e:       Extent{Off: 4, Len: 2},
+			want:    []Extent{{2, 10}},
+		},
+	}
+	for i, test := range tests {
+		got := addExtent(test.extents, test.e)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("tests[%d] extents %v; want %v", i, got, test.want)
+		}
+	}
+}
+
+func TestClipExtents(t *testing.T) {
+	tests := []struct {
+		extents []Extent
+		n       int
+		want    []Extent
+	}{
+		{
+			extents: []Extent{{2, 3}, {10, 5}},
+			n:       20,
+			want:    []Extent{{2, 3}, {10, 5}},
+		}, {
+			extents: []Extent{{2, 3}, {10, 5}},
+			n:       12,
+			want:    []Extent{{2, 3}, {10, 2}},
+		}, {
+			extents: []Extent{{2, 3}, {10, 5}},
+			n:       10,
+			want:    []Extent{{2, 3}},
+		}, {
+			extents: []Extent{{2, 3}, {10, 5}},
+			n:       0,
+			want:    []Extent{},
+		},
+	}
+	for i, test := range tests {
+		got := clipExtents(test.extents, test.n)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("tests[%d] extents %v; want %v", i, got, test.want)
+		}
+	}
+}
+
+func TestFlush(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "flush")
+	if err := ioutil.WriteFile(name, []byte(`0123456789`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(name, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	b := NewWriteSeekBufferBytes([]byte(`0123456789`))
+	defer b.Close()
+
+	b.WriteAt([]byte(`ab`), 1)
+	b.WriteAt([]byte(`c`), 3)
+	b.Truncate(8)
+	b.Seek(12, io.SeekStart)
+	b.Write([]byte(`xy`))
+
+	want := []Extent{{1, 3}, {12, 2}}
+	if got := b.DirtyExtents(); !reflect.DeepEqual(got, want) {
+		t.Errorf("dirty extents %v; want %v", got, want)
+	}
+	if !b.Truncated() {
+		t.Errorf("truncated false; want true")
+	}
+
+	if err := b.Flush(f); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, b.Bytes()) {
+		t.Errorf("file %q; want %q", got, b.Bytes())
+	}
+	if len(b.DirtyExtents()) != 0 || b.Truncated() {
+		t.Errorf("dirty after flush %v %v", b.DirtyExtents(), b.Truncated())
+	}
+}
+
+type errWriterAt struct {
+	n   int
+	err error
+}
+
+func (w *errWriterAt) WriteAt(p []byte, off int64) (int, error) {
+	if w.n == 0 {
+		return 0, w.err
+	}
+	w.n--
+	return len(p), nil
+}
+
+func TestFlush_Error(t *testing.T) {
+	b := NewWriteSeekBuffer(0)
+	defer b.Close()
+
+	b.WriteAt([]byte(`a`), 0)
+	b.WriteAt([]byte(`b`), 2)
+	b.WriteAt([]byte(`c`), 4)
+	b.Truncate(3)
+
+	wantErr := errors.New("test")
+	if err := b.Flush(&errWriterAt{n: 1, err: wantErr}); err != wantErr {
+		t.Fatalf("flush error %v; want %v", err, wantErr)
+	}
+	want := []Extent{{2, 1}}
+	if got := b.DirtyExtents(); !reflect.DeepEqual(got, want) {
+		t.Errorf("dirty extents %v; want %v", got, want)
+	}
+	if !b.Truncated() {
+		t.Errorf("truncated false; want true without Truncate of the writer")
+	}
+}
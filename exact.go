@@ -0,0 +1,2093 @@
+// Code generated by "go run gen_exact.go"; DO NOT EDIT.
+
+package io2
+
+import (
+	"io"
+)
+
+// WrapDelegator returns a value that calls the functions of d and implements
+// only the io interfaces (io.Reader, io.Writer, io.Seeker, io.Closer,
+// io.ReaderAt, io.WriterAt, io.ReaderFrom, io.WriterTo) implemented by i.
+// The returned value never implements io.ByteReader, io.ByteScanner,
+// io.ByteWriter, io.RuneReader, io.RuneScanner or io.StringWriter, even if i does.
+// The returned value also implements DelegatorUnwrapper.
+func WrapDelegator(d *Delegator, i interface{}) interface{} {
+	u := delegatorUnwrapper{d: d}
+	mask := 0
+	if _, ok := i.(io.Reader); ok {
+		mask |= 1
+	}
+	if _, ok := i.(io.Writer); ok {
+		mask |= 2
+	}
+	if _, ok := i.(io.Seeker); ok {
+		mask |= 4
+	}
+	if _, ok := i.(io.Closer); ok {
+		mask |= 8
+	}
+	if _, ok := i.(io.ReaderAt); ok {
+		mask |= 16
+	}
+	if _, ok := i.(io.WriterAt); ok {
+		mask |= 32
+	}
+	if _, ok := i.(io.ReaderFrom); ok {
+		mask |= 64
+	}
+	if _, ok := i.(io.WriterTo); ok {
+		mask |= 128
+	}
+	switch mask {
+	case 0:
+		return struct {
+			DelegatorUnwrapper
+		}{u}
+	case 1: // Reader
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+		}{u, d}
+	case 2: // Writer
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+		}{u, d}
+	case 3: // Reader, Writer
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+		}{u, d, d}
+	case 4: // Seeker
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+		}{u, d}
+	case 5: // Reader, Seeker
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+		}{u, d, d}
+	case 6: // Writer, Seeker
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+		}{u, d, d}
+	case 7: // Reader, Writer, Seeker
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+		}{u, d, d, d}
+	case 8: // Closer
+		return struct {
+			DelegatorUnwrapper
+			io.Closer
+		}{u, d}
+	case 9: // Reader, Closer
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Closer
+		}{u, d, d}
+	case 10: // Writer, Closer
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Closer
+		}{u, d, d}
+	case 11: // Reader, Writer, Closer
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Closer
+		}{u, d, d, d}
+	case 12: // Seeker, Closer
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.Closer
+		}{u, d, d}
+	case 13: // Reader, Seeker, Closer
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.Closer
+		}{u, d, d, d}
+	case 14: // Writer, Seeker, Closer
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.Closer
+		}{u, d, d, d}
+	case 15: // Reader, Writer, Seeker, Closer
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.Closer
+		}{u, d, d, d, d}
+	case 16: // ReaderAt
+		return struct {
+			DelegatorUnwrapper
+			io.ReaderAt
+		}{u, d}
+	case 17: // Reader, ReaderAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.ReaderAt
+		}{u, d, d}
+	case 18: // Writer, ReaderAt
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.ReaderAt
+		}{u, d, d}
+	case 19: // Reader, Writer, ReaderAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.ReaderAt
+		}{u, d, d, d}
+	case 20: // Seeker, ReaderAt
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.ReaderAt
+		}{u, d, d}
+	case 21: // Reader, Seeker, ReaderAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.ReaderAt
+		}{u, d, d, d}
+	case 22: // Writer, Seeker, ReaderAt
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.ReaderAt
+		}{u, d, d, d}
+	case 23: // Reader, Writer, Seeker, ReaderAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.ReaderAt
+		}{u, d, d, d, d}
+	case 24: // Closer, ReaderAt
+		return struct {
+			DelegatorUnwrapper
+			io.Closer
+			io.ReaderAt
+		}{u, d, d}
+	case 25: // Reader, Closer, ReaderAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Closer
+			io.ReaderAt
+		}{u, d, d, d}
+	case 26: // Writer, Closer, ReaderAt
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Closer
+			io.ReaderAt
+		}{u, d, d, d}
+	case 27: // Reader, Writer, Closer, ReaderAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Closer
+			io.ReaderAt
+		}{u, d, d, d, d}
+	case 28: // Seeker, Closer, ReaderAt
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+		}{u, d, d, d}
+	case 29: // Reader, Seeker, Closer, ReaderAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+		}{u, d, d, d, d}
+	case 30: // Writer, Seeker, Closer, ReaderAt
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+		}{u, d, d, d, d}
+	case 31: // Reader, Writer, Seeker, Closer, ReaderAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+		}{u, d, d, d, d, d}
+	case 32: // WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.WriterAt
+		}{u, d}
+	case 33: // Reader, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.WriterAt
+		}{u, d, d}
+	case 34: // Writer, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.WriterAt
+		}{u, d, d}
+	case 35: // Reader, Writer, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.WriterAt
+		}{u, d, d, d}
+	case 36: // Seeker, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.WriterAt
+		}{u, d, d}
+	case 37: // Reader, Seeker, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.WriterAt
+		}{u, d, d, d}
+	case 38: // Writer, Seeker, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.WriterAt
+		}{u, d, d, d}
+	case 39: // Reader, Writer, Seeker, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.WriterAt
+		}{u, d, d, d, d}
+	case 40: // Closer, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Closer
+			io.WriterAt
+		}{u, d, d}
+	case 41: // Reader, Closer, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Closer
+			io.WriterAt
+		}{u, d, d, d}
+	case 42: // Writer, Closer, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Closer
+			io.WriterAt
+		}{u, d, d, d}
+	case 43: // Reader, Writer, Closer, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Closer
+			io.WriterAt
+		}{u, d, d, d, d}
+	case 44: // Seeker, Closer, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.Closer
+			io.WriterAt
+		}{u, d, d, d}
+	case 45: // Reader, Seeker, Closer, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.Closer
+			io.WriterAt
+		}{u, d, d, d, d}
+	case 46: // Writer, Seeker, Closer, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.WriterAt
+		}{u, d, d, d, d}
+	case 47: // Reader, Writer, Seeker, Closer, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.WriterAt
+		}{u, d, d, d, d, d}
+	case 48: // ReaderAt, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.ReaderAt
+			io.WriterAt
+		}{u, d, d}
+	case 49: // Reader, ReaderAt, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.ReaderAt
+			io.WriterAt
+		}{u, d, d, d}
+	case 50: // Writer, ReaderAt, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.ReaderAt
+			io.WriterAt
+		}{u, d, d, d}
+	case 51: // Reader, Writer, ReaderAt, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.ReaderAt
+			io.WriterAt
+		}{u, d, d, d, d}
+	case 52: // Seeker, ReaderAt, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.ReaderAt
+			io.WriterAt
+		}{u, d, d, d}
+	case 53: // Reader, Seeker, ReaderAt, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.ReaderAt
+			io.WriterAt
+		}{u, d, d, d, d}
+	case 54: // Writer, Seeker, ReaderAt, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.ReaderAt
+			io.WriterAt
+		}{u, d, d, d, d}
+	case 55: // Reader, Writer, Seeker, ReaderAt, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.ReaderAt
+			io.WriterAt
+		}{u, d, d, d, d, d}
+	case 56: // Closer, ReaderAt, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+		}{u, d, d, d}
+	case 57: // Reader, Closer, ReaderAt, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+		}{u, d, d, d, d}
+	case 58: // Writer, Closer, ReaderAt, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+		}{u, d, d, d, d}
+	case 59: // Reader, Writer, Closer, ReaderAt, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+		}{u, d, d, d, d, d}
+	case 60: // Seeker, Closer, ReaderAt, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+		}{u, d, d, d, d}
+	case 61: // Reader, Seeker, Closer, ReaderAt, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+		}{u, d, d, d, d, d}
+	case 62: // Writer, Seeker, Closer, ReaderAt, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+		}{u, d, d, d, d, d}
+	case 63: // Reader, Writer, Seeker, Closer, ReaderAt, WriterAt
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+		}{u, d, d, d, d, d, d}
+	case 64: // ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.ReaderFrom
+		}{u, d}
+	case 65: // Reader, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.ReaderFrom
+		}{u, d, d}
+	case 66: // Writer, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.ReaderFrom
+		}{u, d, d}
+	case 67: // Reader, Writer, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.ReaderFrom
+		}{u, d, d, d}
+	case 68: // Seeker, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.ReaderFrom
+		}{u, d, d}
+	case 69: // Reader, Seeker, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.ReaderFrom
+		}{u, d, d, d}
+	case 70: // Writer, Seeker, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.ReaderFrom
+		}{u, d, d, d}
+	case 71: // Reader, Writer, Seeker, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.ReaderFrom
+		}{u, d, d, d, d}
+	case 72: // Closer, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Closer
+			io.ReaderFrom
+		}{u, d, d}
+	case 73: // Reader, Closer, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Closer
+			io.ReaderFrom
+		}{u, d, d, d}
+	case 74: // Writer, Closer, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Closer
+			io.ReaderFrom
+		}{u, d, d, d}
+	case 75: // Reader, Writer, Closer, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Closer
+			io.ReaderFrom
+		}{u, d, d, d, d}
+	case 76: // Seeker, Closer, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.Closer
+			io.ReaderFrom
+		}{u, d, d, d}
+	case 77: // Reader, Seeker, Closer, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.Closer
+			io.ReaderFrom
+		}{u, d, d, d, d}
+	case 78: // Writer, Seeker, Closer, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.ReaderFrom
+		}{u, d, d, d, d}
+	case 79: // Reader, Writer, Seeker, Closer, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.ReaderFrom
+		}{u, d, d, d, d, d}
+	case 80: // ReaderAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.ReaderAt
+			io.ReaderFrom
+		}{u, d, d}
+	case 81: // Reader, ReaderAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.ReaderAt
+			io.ReaderFrom
+		}{u, d, d, d}
+	case 82: // Writer, ReaderAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.ReaderAt
+			io.ReaderFrom
+		}{u, d, d, d}
+	case 83: // Reader, Writer, ReaderAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.ReaderAt
+			io.ReaderFrom
+		}{u, d, d, d, d}
+	case 84: // Seeker, ReaderAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.ReaderAt
+			io.ReaderFrom
+		}{u, d, d, d}
+	case 85: // Reader, Seeker, ReaderAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.ReaderAt
+			io.ReaderFrom
+		}{u, d, d, d, d}
+	case 86: // Writer, Seeker, ReaderAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.ReaderAt
+			io.ReaderFrom
+		}{u, d, d, d, d}
+	case 87: // Reader, Writer, Seeker, ReaderAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.ReaderAt
+			io.ReaderFrom
+		}{u, d, d, d, d, d}
+	case 88: // Closer, ReaderAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Closer
+			io.ReaderAt
+			io.ReaderFrom
+		}{u, d, d, d}
+	case 89: // Reader, Closer, ReaderAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Closer
+			io.ReaderAt
+			io.ReaderFrom
+		}{u, d, d, d, d}
+	case 90: // Writer, Closer, ReaderAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Closer
+			io.ReaderAt
+			io.ReaderFrom
+		}{u, d, d, d, d}
+	case 91: // Reader, Writer, Closer, ReaderAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Closer
+			io.ReaderAt
+			io.ReaderFrom
+		}{u, d, d, d, d, d}
+	case 92: // Seeker, Closer, ReaderAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.ReaderFrom
+		}{u, d, d, d, d}
+	case 93: // Reader, Seeker, Closer, ReaderAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.ReaderFrom
+		}{u, d, d, d, d, d}
+	case 94: // Writer, Seeker, Closer, ReaderAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.ReaderFrom
+		}{u, d, d, d, d, d}
+	case 95: // Reader, Writer, Seeker, Closer, ReaderAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.ReaderFrom
+		}{u, d, d, d, d, d, d}
+	case 96: // WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d}
+	case 97: // Reader, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d}
+	case 98: // Writer, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d}
+	case 99: // Reader, Writer, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d}
+	case 100: // Seeker, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d}
+	case 101: // Reader, Seeker, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d}
+	case 102: // Writer, Seeker, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d}
+	case 103: // Reader, Writer, Seeker, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d, d}
+	case 104: // Closer, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Closer
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d}
+	case 105: // Reader, Closer, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Closer
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d}
+	case 106: // Writer, Closer, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Closer
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d}
+	case 107: // Reader, Writer, Closer, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Closer
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d, d}
+	case 108: // Seeker, Closer, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.Closer
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d}
+	case 109: // Reader, Seeker, Closer, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.Closer
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d, d}
+	case 110: // Writer, Seeker, Closer, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d, d}
+	case 111: // Reader, Writer, Seeker, Closer, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d, d, d}
+	case 112: // ReaderAt, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d}
+	case 113: // Reader, ReaderAt, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d}
+	case 114: // Writer, ReaderAt, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d}
+	case 115: // Reader, Writer, ReaderAt, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d, d}
+	case 116: // Seeker, ReaderAt, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d}
+	case 117: // Reader, Seeker, ReaderAt, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d, d}
+	case 118: // Writer, Seeker, ReaderAt, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d, d}
+	case 119: // Reader, Writer, Seeker, ReaderAt, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d, d, d}
+	case 120: // Closer, ReaderAt, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d}
+	case 121: // Reader, Closer, ReaderAt, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d, d}
+	case 122: // Writer, Closer, ReaderAt, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d, d}
+	case 123: // Reader, Writer, Closer, ReaderAt, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d, d, d}
+	case 124: // Seeker, Closer, ReaderAt, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d, d}
+	case 125: // Reader, Seeker, Closer, ReaderAt, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d, d, d}
+	case 126: // Writer, Seeker, Closer, ReaderAt, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d, d, d}
+	case 127: // Reader, Writer, Seeker, Closer, ReaderAt, WriterAt, ReaderFrom
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+		}{u, d, d, d, d, d, d, d}
+	case 128: // WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.WriterTo
+		}{u, d}
+	case 129: // Reader, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.WriterTo
+		}{u, d, d}
+	case 130: // Writer, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.WriterTo
+		}{u, d, d}
+	case 131: // Reader, Writer, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.WriterTo
+		}{u, d, d, d}
+	case 132: // Seeker, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.WriterTo
+		}{u, d, d}
+	case 133: // Reader, Seeker, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.WriterTo
+		}{u, d, d, d}
+	case 134: // Writer, Seeker, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.WriterTo
+		}{u, d, d, d}
+	case 135: // Reader, Writer, Seeker, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 136: // Closer, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Closer
+			io.WriterTo
+		}{u, d, d}
+	case 137: // Reader, Closer, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Closer
+			io.WriterTo
+		}{u, d, d, d}
+	case 138: // Writer, Closer, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Closer
+			io.WriterTo
+		}{u, d, d, d}
+	case 139: // Reader, Writer, Closer, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Closer
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 140: // Seeker, Closer, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.Closer
+			io.WriterTo
+		}{u, d, d, d}
+	case 141: // Reader, Seeker, Closer, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.Closer
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 142: // Writer, Seeker, Closer, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 143: // Reader, Writer, Seeker, Closer, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 144: // ReaderAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.ReaderAt
+			io.WriterTo
+		}{u, d, d}
+	case 145: // Reader, ReaderAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.ReaderAt
+			io.WriterTo
+		}{u, d, d, d}
+	case 146: // Writer, ReaderAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.ReaderAt
+			io.WriterTo
+		}{u, d, d, d}
+	case 147: // Reader, Writer, ReaderAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.ReaderAt
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 148: // Seeker, ReaderAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.ReaderAt
+			io.WriterTo
+		}{u, d, d, d}
+	case 149: // Reader, Seeker, ReaderAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.ReaderAt
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 150: // Writer, Seeker, ReaderAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.ReaderAt
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 151: // Reader, Writer, Seeker, ReaderAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.ReaderAt
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 152: // Closer, ReaderAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Closer
+			io.ReaderAt
+			io.WriterTo
+		}{u, d, d, d}
+	case 153: // Reader, Closer, ReaderAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Closer
+			io.ReaderAt
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 154: // Writer, Closer, ReaderAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Closer
+			io.ReaderAt
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 155: // Reader, Writer, Closer, ReaderAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Closer
+			io.ReaderAt
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 156: // Seeker, Closer, ReaderAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 157: // Reader, Seeker, Closer, ReaderAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 158: // Writer, Seeker, Closer, ReaderAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 159: // Reader, Writer, Seeker, Closer, ReaderAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.WriterTo
+		}{u, d, d, d, d, d, d}
+	case 160: // WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d}
+	case 161: // Reader, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d}
+	case 162: // Writer, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d}
+	case 163: // Reader, Writer, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 164: // Seeker, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d}
+	case 165: // Reader, Seeker, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 166: // Writer, Seeker, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 167: // Reader, Writer, Seeker, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 168: // Closer, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Closer
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d}
+	case 169: // Reader, Closer, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Closer
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 170: // Writer, Closer, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Closer
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 171: // Reader, Writer, Closer, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Closer
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 172: // Seeker, Closer, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.Closer
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 173: // Reader, Seeker, Closer, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.Closer
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 174: // Writer, Seeker, Closer, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 175: // Reader, Writer, Seeker, Closer, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d, d, d}
+	case 176: // ReaderAt, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.ReaderAt
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d}
+	case 177: // Reader, ReaderAt, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.ReaderAt
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 178: // Writer, ReaderAt, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.ReaderAt
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 179: // Reader, Writer, ReaderAt, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.ReaderAt
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 180: // Seeker, ReaderAt, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.ReaderAt
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 181: // Reader, Seeker, ReaderAt, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.ReaderAt
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 182: // Writer, Seeker, ReaderAt, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.ReaderAt
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 183: // Reader, Writer, Seeker, ReaderAt, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.ReaderAt
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d, d, d}
+	case 184: // Closer, ReaderAt, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 185: // Reader, Closer, ReaderAt, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 186: // Writer, Closer, ReaderAt, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 187: // Reader, Writer, Closer, ReaderAt, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d, d, d}
+	case 188: // Seeker, Closer, ReaderAt, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 189: // Reader, Seeker, Closer, ReaderAt, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d, d, d}
+	case 190: // Writer, Seeker, Closer, ReaderAt, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d, d, d}
+	case 191: // Reader, Writer, Seeker, Closer, ReaderAt, WriterAt, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.WriterTo
+		}{u, d, d, d, d, d, d, d}
+	case 192: // ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d}
+	case 193: // Reader, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d}
+	case 194: // Writer, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d}
+	case 195: // Reader, Writer, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 196: // Seeker, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d}
+	case 197: // Reader, Seeker, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 198: // Writer, Seeker, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 199: // Reader, Writer, Seeker, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 200: // Closer, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Closer
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d}
+	case 201: // Reader, Closer, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Closer
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 202: // Writer, Closer, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Closer
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 203: // Reader, Writer, Closer, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Closer
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 204: // Seeker, Closer, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.Closer
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 205: // Reader, Seeker, Closer, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.Closer
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 206: // Writer, Seeker, Closer, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 207: // Reader, Writer, Seeker, Closer, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d, d}
+	case 208: // ReaderAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.ReaderAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d}
+	case 209: // Reader, ReaderAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.ReaderAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 210: // Writer, ReaderAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.ReaderAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 211: // Reader, Writer, ReaderAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.ReaderAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 212: // Seeker, ReaderAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.ReaderAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 213: // Reader, Seeker, ReaderAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.ReaderAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 214: // Writer, Seeker, ReaderAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.ReaderAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 215: // Reader, Writer, Seeker, ReaderAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.ReaderAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d, d}
+	case 216: // Closer, ReaderAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Closer
+			io.ReaderAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 217: // Reader, Closer, ReaderAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Closer
+			io.ReaderAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 218: // Writer, Closer, ReaderAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Closer
+			io.ReaderAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 219: // Reader, Writer, Closer, ReaderAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Closer
+			io.ReaderAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d, d}
+	case 220: // Seeker, Closer, ReaderAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 221: // Reader, Seeker, Closer, ReaderAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d, d}
+	case 222: // Writer, Seeker, Closer, ReaderAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d, d}
+	case 223: // Reader, Writer, Seeker, Closer, ReaderAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d, d, d}
+	case 224: // WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d}
+	case 225: // Reader, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 226: // Writer, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 227: // Reader, Writer, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 228: // Seeker, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 229: // Reader, Seeker, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 230: // Writer, Seeker, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 231: // Reader, Writer, Seeker, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d, d}
+	case 232: // Closer, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Closer
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 233: // Reader, Closer, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Closer
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 234: // Writer, Closer, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Closer
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 235: // Reader, Writer, Closer, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Closer
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d, d}
+	case 236: // Seeker, Closer, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.Closer
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 237: // Reader, Seeker, Closer, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.Closer
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d, d}
+	case 238: // Writer, Seeker, Closer, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d, d}
+	case 239: // Reader, Writer, Seeker, Closer, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d, d, d}
+	case 240: // ReaderAt, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d}
+	case 241: // Reader, ReaderAt, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 242: // Writer, ReaderAt, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 243: // Reader, Writer, ReaderAt, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d, d}
+	case 244: // Seeker, ReaderAt, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 245: // Reader, Seeker, ReaderAt, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d, d}
+	case 246: // Writer, Seeker, ReaderAt, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d, d}
+	case 247: // Reader, Writer, Seeker, ReaderAt, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d, d, d}
+	case 248: // Closer, ReaderAt, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d}
+	case 249: // Reader, Closer, ReaderAt, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d, d}
+	case 250: // Writer, Closer, ReaderAt, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d, d}
+	case 251: // Reader, Writer, Closer, ReaderAt, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d, d, d}
+	case 252: // Seeker, Closer, ReaderAt, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d, d}
+	case 253: // Reader, Seeker, Closer, ReaderAt, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d, d, d}
+	case 254: // Writer, Seeker, Closer, ReaderAt, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d, d, d}
+	case 255: // Reader, Writer, Seeker, Closer, ReaderAt, WriterAt, ReaderFrom, WriterTo
+		return struct {
+			DelegatorUnwrapper
+			io.Reader
+			io.Writer
+			io.Seeker
+			io.Closer
+			io.ReaderAt
+			io.WriterAt
+			io.ReaderFrom
+			io.WriterTo
+		}{u, d, d, d, d, d, d, d, d}
+	}
+	panic("unreachable")
+}
@@ -1,6 +1,7 @@
 package io2_test
 
 import (
+	"archive/zip"
 	"bytes"
 	"errors"
 	"fmt"
@@ -44,6 +45,25 @@ func ExampleNewWriteSeekBuffer() {
 	// Hello world?
 }
 
+func ExampleWriteSeekBuffer_Read() {
+	b := io2.NewWriteSeekBuffer(0)
+	zw := zip.NewWriter(b)
+	f, _ := zw.Create("hello.txt")
+	f.Write([]byte(`Hello world!`))
+	zw.Close()
+
+	zr, _ := zip.NewReader(b, int64(b.Len()))
+	rc, _ := zr.File[0].Open()
+	defer rc.Close()
+	io.Copy(ioutil.Discard, rc)
+
+	b.Seek(0, io.SeekStart)
+	n, _ := io.Copy(ioutil.Discard, b)
+	fmt.Println(zr.File[0].Name, n == int64(b.Len()))
+
+	// Output: hello.txt true
+}
+
 func ExampleMultiReadSeeker() {
 	r, _ := io2.NewMultiReadSeeker(
 		strings.NewReader("Hello !"),
@@ -62,3 +82,45 @@ func ExampleMultiReadSeeker() {
 	// ! World
 	// World
 }
+
+func ExampleDelegateReaderAt() {
+	org := strings.NewReader(`original`)
+
+	r := io2.DelegateReaderAt(org)
+	r.ReadAtFunc = func(p []byte, off int64) (int, error) {
+		if off >= 4 {
+			return 0, errors.New("custom")
+		}
+		return org.ReadAt(p, off)
+	}
+
+	p := make([]byte, 4)
+	n, err := r.ReadAt(p, 0)
+	fmt.Printf("%s %v\n", p[:n], err)
+	_, err = r.ReadAt(p, 4)
+	fmt.Printf("Error: %v\n", err)
+
+	// Output:
+	// orig <nil>
+	// Error: custom
+}
+
+func ExampleDelegator_Use() {
+	var reads, total int
+	count := func(next *io2.Delegator) *io2.Delegator {
+		return &io2.Delegator{
+			ReadFunc: func(p []byte) (int, error) {
+				n, err := next.Read(p)
+				reads++
+				total += n
+				return n, err
+			},
+		}
+	}
+
+	r := io2.DelegateReader(strings.NewReader(`original`)).Use(count)
+	ioutil.ReadAll(r)
+	fmt.Printf("reads: %d, bytes: %d\n", reads, total)
+
+	// Output: reads: 2, bytes: 8
+}
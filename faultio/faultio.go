@@ -0,0 +1,241 @@
+// Package faultio provides fault injection for the io interfaces using io2.Delegator.
+package faultio
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/jarxorg/io2"
+)
+
+// Fault alters calls of an operation.
+type Fault struct {
+	// Calls is the number of calls the fault applies to. Zero means a single
+	// call and a negative value means all remaining calls.
+	Calls int
+	// After defers the fault until the operation has transferred After bytes.
+	// Until then the calls pass through, and are limited not to cross After.
+	// After is ignored by Seek and Close.
+	After int64
+	// Limit limits the length of the buffer passed to the underlying Read or
+	// Write if positive.
+	Limit int
+	// Skip skips the underlying call.
+	Skip bool
+	// Err is returned by the call.
+	Err error
+	// Delay sleeps before the call.
+	Delay time.Duration
+}
+
+// Schedule is a declarative list of faults for each operation.
+// The faults are applied in order, and calls pass through after all faults are applied.
+type Schedule struct {
+	Read  []Fault
+	Write []Fault
+	Seek  []Fault
+	Close []Fault
+}
+
+func (s Schedule) empty() bool {
+	return len(s.Read) == 0 && len(s.Write) == 0 && len(s.Seek) == 0 && len(s.Close) == 0
+}
+
+// Merge returns a Schedule that applies the faults of ss in order.
+func Merge(ss ...Schedule) Schedule {
+	var m Schedule
+	for _, s := range ss {
+		m.Read = append(m.Read, s.Read...)
+		m.Write = append(m.Write, s.Write...)
+		m.Seek = append(m.Seek, s.Seek...)
+		m.Close = append(m.Close, s.Close...)
+	}
+	return m
+}
+
+// ShortReads returns a Schedule that reads at most n bytes by each Read.
+func ShortReads(n int) Schedule {
+	return Schedule{Read: []Fault{{Calls: -1, Limit: n}}}
+}
+
+// ZeroReads returns a Schedule that the first calls of Read return 0, nil.
+func ZeroReads(calls int) Schedule {
+	return Schedule{Read: []Fault{{Calls: calls, Skip: true}}}
+}
+
+// ReadErrAfter returns a Schedule that Read returns err after reading n bytes.
+func ReadErrAfter(n int64, err error) Schedule {
+	return Schedule{Read: []Fault{{Calls: -1, After: n, Skip: true, Err: err}}}
+}
+
+// TransientReadErr returns a Schedule that the first Read returns err and the following Read succeed.
+func TransientReadErr(err error) Schedule {
+	return Schedule{Read: []Fault{{Skip: true, Err: err}}}
+}
+
+// WriteErrAfter returns a Schedule that Write returns err after writing n bytes.
+func WriteErrAfter(n int64, err error) Schedule {
+	return Schedule{Write: []Fault{{Calls: -1, After: n, Skip: true, Err: err}}}
+}
+
+// TransientWriteErr returns a Schedule that the first Write returns err and the following Write succeed.
+func TransientWriteErr(err error) Schedule {
+	return Schedule{Write: []Fault{{Skip: true, Err: err}}}
+}
+
+// PartialWrite returns a Schedule that the first Write writes at most n bytes without an error.
+func PartialWrite(n int) Schedule {
+	return Schedule{Write: []Fault{{Limit: n}}}
+}
+
+// ShortWrite returns a Schedule that the first Write writes at most n bytes and returns io.ErrShortWrite.
+func ShortWrite(n int) Schedule {
+	return Schedule{Write: []Fault{{Limit: n, Err: io.ErrShortWrite}}}
+}
+
+// SeekErr returns a Schedule that the first Seek returns err.
+func SeekErr(err error) Schedule {
+	return Schedule{Seek: []Fault{{Skip: true, Err: err}}}
+}
+
+// CloseErr returns a Schedule that Close returns err after closing.
+func CloseErr(err error) Schedule {
+	return Schedule{Close: []Fault{{Calls: -1, Err: err}}}
+}
+
+type script struct {
+	faults []Fault
+	calls  int
+	total  int64
+}
+
+// take returns the fault for a call with the size and the limited size.
+func (s *script) take(size int) (*Fault, int) {
+	if len(s.faults) == 0 {
+		return nil, size
+	}
+	f := s.faults[0]
+	if rest := f.After - s.total; rest > 0 {
+		if int64(size) > rest {
+			size = int(rest)
+		}
+		return nil, size
+	}
+	s.calls++
+	if f.Calls >= 0 && s.calls >= f.Calls {
+		s.faults = s.faults[1:]
+		s.calls = 0
+	}
+	if f.Limit > 0 && f.Limit < size {
+		size = f.Limit
+	}
+	return &f, size
+}
+
+type injector struct {
+	mu    sync.Mutex
+	read  *script
+	write *script
+	seek  *script
+	close *script
+}
+
+func newInjector(s Schedule) *injector {
+	clone := func(fs []Fault) *script {
+		return &script{faults: append([]Fault(nil), fs...)}
+	}
+	return &injector{
+		read:  clone(s.Read),
+		write: clone(s.Write),
+		seek:  clone(s.Seek),
+		close: clone(s.Close),
+	}
+}
+
+func (j *injector) take(s *script, size int) (*Fault, int) {
+	j.mu.Lock()
+	f, size := s.take(size)
+	j.mu.Unlock()
+	if f != nil && f.Delay > 0 {
+		time.Sleep(f.Delay)
+	}
+	return f, size
+}
+
+func (j *injector) done(s *script, n int) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	s.total += int64(n)
+}
+
+func (j *injector) rw(s *script, fn func(p []byte) (int, error), p []byte) (int, error) {
+	f, size := j.take(s, len(p))
+	if f != nil && f.Skip {
+		return 0, f.Err
+	}
+	n, err := fn(p[:size])
+	j.done(s, n)
+	if f != nil && f.Err != nil {
+		return n, f.Err
+	}
+	return n, err
+}
+
+// Middleware returns a io2.Middleware that injects the faults of s into
+// Read, Write, Seek and Close. Each returned Middleware has its own state.
+func (s Schedule) Middleware() io2.Middleware {
+	j := newInjector(s)
+	return func(next *io2.Delegator) *io2.Delegator {
+		return &io2.Delegator{
+			ReadFunc: func(p []byte) (int, error) {
+				return j.rw(j.read, next.Read, p)
+			},
+			WriteFunc: func(p []byte) (int, error) {
+				return j.rw(j.write, next.Write, p)
+			},
+			SeekFunc: func(offset int64, whence int) (int64, error) {
+				f, _ := j.take(j.seek, 0)
+				if f != nil && f.Skip {
+					return 0, f.Err
+				}
+				n, err := next.Seek(offset, whence)
+				if f != nil && f.Err != nil {
+					return n, f.Err
+				}
+				return n, err
+			},
+			CloseFunc: func() error {
+				f, _ := j.take(j.close, 0)
+				if f != nil && f.Skip {
+					return f.Err
+				}
+				err := next.Close()
+				if f != nil && f.Err != nil {
+					return f.Err
+				}
+				return err
+			},
+		}
+	}
+}
+
+// New returns a Delegator that injects the faults of s into the Read, Write,
+// Seek and Close methods of i. Other methods such as WriteTo and ReadByte
+// are served by the injected Read and Write.
+func New(i interface{}, s Schedule) *io2.Delegator {
+	d := &io2.Delegator{}
+	if r, ok := i.(io.Reader); ok {
+		d.ReadFunc = r.Read
+	}
+	if w, ok := i.(io.Writer); ok {
+		d.WriteFunc = w.Write
+	}
+	if sk, ok := i.(io.Seeker); ok {
+		d.SeekFunc = sk.Seek
+	}
+	if c, ok := i.(io.Closer); ok {
+		d.CloseFunc = c.Close
+	}
+	return d.Use(s.Middleware())
+}
@@ -0,0 +1,163 @@
+package faultio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type call struct {
+	n   int
+	err error
+}
+
+func TestRead(t *testing.T) {
+	errTest := errors.New("test")
+	tests := []struct {
+		schedule Schedule
+		size     int
+		want     []call
+	}{
+		{
+			schedule: ShortReads(2),
+			size:     4,
+			want:     []call{{n: 2}, {n: 2}, {n: 2}, {n: 0, err: io.EOF}},
+		}, {
+			schedule: ZeroReads(2),
+			size:     4,
+			want:     []call{{n: 0}, {n: 0}, {n: 4}, {n: 2}, {n: 0, err: io.EOF}},
+		}, {
+			schedule: ReadErrAfter(5, errTest),
+			size:     4,
+			want:     []call{{n: 4}, {n: 1}, {n: 0, err: errTest}, {n: 0, err: errTest}},
+		}, {
+			schedule: TransientReadErr(errTest),
+			size:     4,
+			want:     []call{{n: 0, err: errTest}, {n: 4}, {n: 2}, {n: 0, err: io.EOF}},
+		}, {
+			schedule: Schedule{Read: []Fault{{After: 3, Limit: 1, Err: errTest}}},
+			size:     4,
+			want:     []call{{n: 3}, {n: 1, err: errTest}, {n: 2}, {n: 0, err: io.EOF}},
+		}, {
+			schedule: Merge(ZeroReads(1), ShortReads(5)),
+			size:     6,
+			want:     []call{{n: 0}, {n: 5}, {n: 1}, {n: 0, err: io.EOF}},
+		},
+	}
+	for i, test := range tests {
+		r := New(strings.NewReader("abcdef"), test.schedule)
+		var got []call
+		for range test.want {
+			n, err := r.Read(make([]byte, test.size))
+			got = append(got, call{n: n, err: err})
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("tests[%d] got %v; want %v", i, got, test.want)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	errTest := errors.New("test")
+	tests := []struct {
+		schedule Schedule
+		want     []call
+		written  string
+	}{
+		{
+			schedule: PartialWrite(2),
+			want:     []call{{n: 2}, {n: 3}},
+			written:  "ababc",
+		}, {
+			schedule: ShortWrite(1),
+			want:     []call{{n: 1, err: io.ErrShortWrite}, {n: 3}},
+			written:  "aabc",
+		}, {
+			schedule: WriteErrAfter(4, errTest),
+			want:     []call{{n: 3}, {n: 1}, {n: 0, err: errTest}},
+			written:  "abca",
+		}, {
+			schedule: TransientWriteErr(errTest),
+			want:     []call{{n: 0, err: errTest}, {n: 3}},
+			written:  "abc",
+		},
+	}
+	for i, test := range tests {
+		buf := &bytes.Buffer{}
+		w := New(buf, test.schedule)
+		var got []call
+		for range test.want {
+			n, err := w.Write([]byte("abc"))
+			got = append(got, call{n: n, err: err})
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("tests[%d] got %v; want %v", i, got, test.want)
+		}
+		if buf.String() != test.written {
+			t.Errorf("tests[%d] written %s; want %s", i, buf.String(), test.written)
+		}
+	}
+}
+
+func TestSeekClose(t *testing.T) {
+	errTest := errors.New("test")
+	closed := 0
+	c := struct {
+		io.ReadSeeker
+		io.Closer
+	}{
+		ReadSeeker: strings.NewReader("abc"),
+		Closer: closeFunc(func() error {
+			closed++
+			return nil
+		}),
+	}
+	d := New(c, Merge(SeekErr(errTest), CloseErr(errTest)))
+	if _, err := d.Seek(1, io.SeekStart); err != errTest {
+		t.Errorf("seek error %v; want %v", err, errTest)
+	}
+	if n, err := d.Seek(1, io.SeekStart); err != nil || n != 1 {
+		t.Errorf("seek %d %v; want %d", n, err, 1)
+	}
+	if err := d.Close(); err != errTest {
+		t.Errorf("close error %v; want %v", err, errTest)
+	}
+	if closed != 1 {
+		t.Errorf("closed %d; want %d", closed, 1)
+	}
+}
+
+func TestCopy(t *testing.T) {
+	errTest := errors.New("test")
+	r := New(strings.NewReader("abcdef"), ReadErrAfter(3, errTest))
+	buf := &bytes.Buffer{}
+	n, err := io.Copy(buf, r)
+	if err != errTest {
+		t.Errorf("error %v; want %v", err, errTest)
+	}
+	if n != 3 || buf.String() != "abc" {
+		t.Errorf("copied %d %s; want %d %s", n, buf.String(), 3, "abc")
+	}
+}
+
+func TestDelay(t *testing.T) {
+	r := New(strings.NewReader("abc"), Schedule{Read: []Fault{{Delay: 10 * time.Millisecond}}})
+	start := time.Now()
+	if _, err := ioutil.ReadAll(r); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d < 10*time.Millisecond {
+		t.Errorf("delay %v", d)
+	}
+}
+
+type closeFunc func() error
+
+func (f closeFunc) Close() error {
+	return f()
+}
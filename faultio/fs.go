@@ -0,0 +1,184 @@
+package faultio
+
+import (
+	"io"
+	"io/fs"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/jarxorg/io2"
+)
+
+// FSOp represents an operation of a file system.
+type FSOp string
+
+const (
+	// FSOpen represents fs.FS.Open.
+	FSOpen FSOp = "open"
+	// FSStat represents fs.StatFS.Stat.
+	FSStat FSOp = "stat"
+	// FSReadDir represents fs.ReadDirFS.ReadDir and fs.ReadDirFile.ReadDir.
+	FSReadDir FSOp = "readdir"
+	// FSReadFile represents fs.ReadFileFS.ReadFile.
+	FSReadFile FSOp = "readfile"
+)
+
+// Rule injects faults into the operations of the names that match Pattern.
+type Rule struct {
+	// Pattern is a path.Match pattern of the names. Empty matches all names.
+	Pattern string
+	// Ops are the operations the rule applies to. Empty means all operations.
+	Ops []FSOp
+	// Times limits the rule to the first matching calls if positive.
+	Times int
+	// Err is returned by the operation as the Err of fs.PathError.
+	Err error
+	// Delay sleeps before the operation.
+	Delay time.Duration
+	// File is the schedule of the opened files, such as ReadErrAfter that
+	// fails in the middle of reading.
+	File Schedule
+	// Limit truncates the results of ReadDir to at most Limit entries if positive.
+	Limit int
+}
+
+func (r *Rule) match(op FSOp, name string) bool {
+	if r.Pattern != "" {
+		if ok, _ := path.Match(r.Pattern, name); !ok {
+			return false
+		}
+	}
+	if len(r.Ops) == 0 {
+		return true
+	}
+	for _, o := range r.Ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+type fsInjector struct {
+	mu    sync.Mutex
+	rules []Rule
+	calls []int
+}
+
+// apply returns the first matching rule after the delay, and the error of the rule.
+func (j *fsInjector) apply(op FSOp, name string) (*Rule, error) {
+	j.mu.Lock()
+	var rule *Rule
+	for i := range j.rules {
+		r := &j.rules[i]
+		if !r.match(op, name) {
+			continue
+		}
+		if r.Times > 0 {
+			if j.calls[i] >= r.Times {
+				continue
+			}
+			j.calls[i]++
+		}
+		rule = r
+		break
+	}
+	j.mu.Unlock()
+	if rule == nil {
+		return nil, nil
+	}
+	if rule.Delay > 0 {
+		time.Sleep(rule.Delay)
+	}
+	if rule.Err != nil {
+		return rule, &fs.PathError{Op: string(op), Path: name, Err: rule.Err}
+	}
+	return rule, nil
+}
+
+// openFS implements only fs.FS to use the helper functions of io/fs.
+type openFS func(name string) (fs.File, error)
+
+func (f openFS) Open(name string) (fs.File, error) {
+	return f(name)
+}
+
+func limitEntries(entries []fs.DirEntry, rule *Rule) []fs.DirEntry {
+	if rule != nil && rule.Limit > 0 && len(entries) > rule.Limit {
+		return entries[:rule.Limit]
+	}
+	return entries
+}
+
+// NewFS returns a FSDelegator that injects the faults of the rules into fsys.
+// The first matching rule is applied to each operation. The opened files are
+// FileDelegator whose Read, Write, Seek and Close are injected by Rule.File.
+// The other methods of the files such as ReadAt are kept; WriteTo, ReadByte
+// and the like are served by the injected Read and Write.
+// ReadFile reads the file opened by Open, so that the rules of FSOpen and
+// Rule.File are also applied.
+func NewFS(fsys fs.FS, rules ...Rule) *io2.FSDelegator {
+	j := &fsInjector{
+		rules: append([]Rule(nil), rules...),
+		calls: make([]int, len(rules)),
+	}
+	d := &io2.FSDelegator{}
+	d.OpenFunc = func(name string) (fs.File, error) {
+		rule, err := j.apply(FSOpen, name)
+		if err != nil {
+			return nil, err
+		}
+		f, err := fsys.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		fd := io2.DelegateFile(f)
+		if rule != nil && !rule.File.empty() {
+			fd.Use(rule.File.Middleware())
+		}
+		if r, ok := f.(fs.ReadDirFile); ok {
+			read := 0
+			fd.ReadDirFunc = func(n int) ([]fs.DirEntry, error) {
+				rule, err := j.apply(FSReadDir, name)
+				if err != nil {
+					return nil, err
+				}
+				if rule != nil && rule.Limit > 0 && read >= rule.Limit {
+					if n > 0 {
+						return nil, io.EOF
+					}
+					return []fs.DirEntry{}, nil
+				}
+				entries, err := r.ReadDir(n)
+				if rule != nil && rule.Limit > 0 && read+len(entries) > rule.Limit {
+					entries = entries[:rule.Limit-read]
+				}
+				read += len(entries)
+				return entries, err
+			}
+		}
+		return fd, nil
+	}
+	d.StatFunc = func(name string) (fs.FileInfo, error) {
+		if _, err := j.apply(FSStat, name); err != nil {
+			return nil, err
+		}
+		return fs.Stat(fsys, name)
+	}
+	d.ReadDirFunc = func(name string) ([]fs.DirEntry, error) {
+		rule, err := j.apply(FSReadDir, name)
+		if err != nil {
+			return nil, err
+		}
+		entries, err := fs.ReadDir(fsys, name)
+		return limitEntries(entries, rule), err
+	}
+	d.ReadFileFunc = func(name string) ([]byte, error) {
+		if _, err := j.apply(FSReadFile, name); err != nil {
+			return nil, err
+		}
+		return fs.ReadFile(openFS(d.Open), name)
+	}
+	return d
+}
@@ -0,0 +1,167 @@
+package faultio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"syscall"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"config.yaml":  {Data: []byte("key: value")},
+		"assets/a.css": {Data: []byte("a")},
+		"assets/b.css": {Data: []byte("b")},
+		"assets/c.css": {Data: []byte("c")},
+	}
+}
+
+func TestNewFS_Errors(t *testing.T) {
+	tests := []struct {
+		rule Rule
+		fn   func(fsys fs.FS) error
+		err  error
+	}{
+		{
+			rule: Rule{Pattern: "*.yaml", Ops: []FSOp{FSStat}, Err: fs.ErrNotExist},
+			fn: func(fsys fs.FS) error {
+				_, err := fs.Stat(fsys, "config.yaml")
+				return err
+			},
+			err: fs.ErrNotExist,
+		}, {
+			rule: Rule{Pattern: "assets/*", Ops: []FSOp{FSOpen}, Err: fs.ErrPermission},
+			fn: func(fsys fs.FS) error {
+				_, err := fsys.Open("assets/a.css")
+				return err
+			},
+			err: fs.ErrPermission,
+		}, {
+			rule: Rule{Ops: []FSOp{FSOpen}, File: ReadErrAfter(4, syscall.EIO)},
+			fn: func(fsys fs.FS) error {
+				_, err := fs.ReadFile(fsys, "config.yaml")
+				return err
+			},
+			err: syscall.EIO,
+		}, {
+			rule: Rule{Ops: []FSOp{FSReadFile}, Err: syscall.EIO},
+			fn: func(fsys fs.FS) error {
+				_, err := fs.ReadFile(fsys, "config.yaml")
+				return err
+			},
+			err: syscall.EIO,
+		}, {
+			rule: Rule{Pattern: "assets", Err: fs.ErrPermission},
+			fn: func(fsys fs.FS) error {
+				_, err := fs.ReadDir(fsys, "assets")
+				return err
+			},
+			err: fs.ErrPermission,
+		},
+	}
+	for i, test := range tests {
+		fsys := NewFS(testFS(), test.rule)
+		err := test.fn(fsys)
+		if !errors.Is(err, test.err) {
+			t.Errorf("tests[%d] error %v; want %v", i, err, test.err)
+		}
+		var pathErr *fs.PathError
+		if test.rule.Err != nil && !errors.As(err, &pathErr) {
+			t.Errorf("tests[%d] error %v is not fs.PathError", i, err)
+		}
+	}
+}
+
+func TestNewFS_Passthrough(t *testing.T) {
+	fsys := NewFS(testFS(), Rule{Pattern: "none"})
+	if err := fstest.TestFS(fsys, "config.yaml", "assets/a.css", "assets/b.css", "assets/c.css"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewFS_FileMethods(t *testing.T) {
+	fsys := NewFS(testFS(), Rule{Ops: []FSOp{FSOpen}, File: ReadErrAfter(4, syscall.EIO)})
+	f, err := fsys.Open("config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	p := make([]byte, 5)
+	if _, err := f.(io.ReaderAt).ReadAt(p, 5); err != nil {
+		t.Fatal(err)
+	}
+	if string(p) != "value" {
+		t.Errorf("readat %s; want %s", p, "value")
+	}
+	buf := &bytes.Buffer{}
+	if _, err := f.(io.WriterTo).WriteTo(buf); !errors.Is(err, syscall.EIO) {
+		t.Errorf("writeto error %v; want %v", err, syscall.EIO)
+	}
+	if buf.String() != "key:" {
+		t.Errorf("writeto %s; want %s", buf.String(), "key:")
+	}
+}
+
+func TestNewFS_Times(t *testing.T) {
+	fsys := NewFS(testFS(), Rule{Ops: []FSOp{FSOpen}, Times: 1, Err: syscall.EAGAIN})
+	if _, err := fsys.Open("config.yaml"); !errors.Is(err, syscall.EAGAIN) {
+		t.Errorf("first open error %v; want %v", err, syscall.EAGAIN)
+	}
+	f, err := fsys.Open("config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+}
+
+func TestNewFS_Limit(t *testing.T) {
+	fsys := NewFS(testFS(), Rule{Pattern: "assets", Ops: []FSOp{FSReadDir}, Limit: 2})
+	entries, err := fs.ReadDir(fsys, "assets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("ReadDir %d entries; want %d", len(entries), 2)
+	}
+
+	f, err := fsys.Open("assets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	d := f.(fs.ReadDirFile)
+	var names []string
+	for {
+		entries, err := d.ReadDir(1)
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(names) != 2 {
+		t.Errorf("ReadDir(1) %v; want %d entries", names, 2)
+	}
+}
+
+func TestNewFS_Delay(t *testing.T) {
+	fsys := NewFS(testFS(), Rule{Ops: []FSOp{FSOpen}, Delay: 10 * time.Millisecond})
+	start := time.Now()
+	f, err := fsys.Open("config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	if d := time.Since(start); d < 10*time.Millisecond {
+		t.Errorf("delay %v", d)
+	}
+}
@@ -0,0 +1,335 @@
+package io2
+
+import (
+	"io/fs"
+	"time"
+)
+
+// FSDelegator implements fs.FS, fs.StatFS, fs.ReadDirFS, fs.ReadFileFS, fs.GlobFS and fs.SubFS.
+type FSDelegator struct {
+	OpenFunc     func(name string) (fs.File, error)
+	StatFunc     func(name string) (fs.FileInfo, error)
+	ReadDirFunc  func(name string) ([]fs.DirEntry, error)
+	ReadFileFunc func(name string) ([]byte, error)
+	GlobFunc     func(pattern string) ([]string, error)
+	SubFunc      func(dir string) (fs.FS, error)
+}
+
+var (
+	_ fs.FS         = (*FSDelegator)(nil)
+	_ fs.StatFS     = (*FSDelegator)(nil)
+	_ fs.ReadDirFS  = (*FSDelegator)(nil)
+	_ fs.ReadFileFS = (*FSDelegator)(nil)
+	_ fs.GlobFS     = (*FSDelegator)(nil)
+	_ fs.SubFS      = (*FSDelegator)(nil)
+)
+
+// openFS implements only fs.FS to use the helper functions of io/fs.
+type openFS func(name string) (fs.File, error)
+
+func (f openFS) Open(name string) (fs.File, error) {
+	return f(name)
+}
+
+// Open calls OpenFunc(name).
+func (d *FSDelegator) Open(name string) (fs.File, error) {
+	if d.OpenFunc == nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: ErrNotImplemented}
+	}
+	return d.OpenFunc(name)
+}
+
+// Stat calls StatFunc(name).
+// If StatFunc is nil and OpenFunc is not nil, Stat calls fs.Stat using Open.
+func (d *FSDelegator) Stat(name string) (fs.FileInfo, error) {
+	if d.StatFunc == nil {
+		if d.OpenFunc == nil {
+			return nil, &fs.PathError{Op: "stat", Path: name, Err: ErrNotImplemented}
+		}
+		return fs.Stat(openFS(d.Open), name)
+	}
+	return d.StatFunc(name)
+}
+
+// ReadDir calls ReadDirFunc(name).
+// If ReadDirFunc is nil and OpenFunc is not nil, ReadDir calls fs.ReadDir using Open.
+func (d *FSDelegator) ReadDir(name string) ([]fs.DirEntry, error) {
+	if d.ReadDirFunc == nil {
+		if d.OpenFunc == nil {
+			return nil, &fs.PathError{Op: "readdir", Path: name, Err: ErrNotImplemented}
+		}
+		return fs.ReadDir(openFS(d.Open), name)
+	}
+	return d.ReadDirFunc(name)
+}
+
+// ReadFile calls ReadFileFunc(name).
+// If ReadFileFunc is nil and OpenFunc is not nil, ReadFile calls fs.ReadFile using Open.
+func (d *FSDelegator) ReadFile(name string) ([]byte, error) {
+	if d.ReadFileFunc == nil {
+		if d.OpenFunc == nil {
+			return nil, &fs.PathError{Op: "readfile", Path: name, Err: ErrNotImplemented}
+		}
+		return fs.ReadFile(openFS(d.Open), name)
+	}
+	return d.ReadFileFunc(name)
+}
+
+// Glob calls GlobFunc(pattern).
+// If GlobFunc is nil and OpenFunc is not nil, Glob calls fs.Glob using Open, Stat and ReadDir.
+func (d *FSDelegator) Glob(pattern string) ([]string, error) {
+	if d.GlobFunc == nil {
+		if d.OpenFunc == nil {
+			return nil, &fs.PathError{Op: "glob", Path: pattern, Err: ErrNotImplemented}
+		}
+		return fs.Glob(globFS{d}, pattern)
+	}
+	return d.GlobFunc(pattern)
+}
+
+// globFS hides Glob of FSDelegator to use fs.Glob.
+type globFS struct {
+	d *FSDelegator
+}
+
+func (f globFS) Open(name string) (fs.File, error) {
+	return f.d.Open(name)
+}
+
+func (f globFS) Stat(name string) (fs.FileInfo, error) {
+	return f.d.Stat(name)
+}
+
+func (f globFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return f.d.ReadDir(name)
+}
+
+// Sub calls SubFunc(dir).
+// If SubFunc is nil and OpenFunc is not nil, Sub calls fs.Sub using Open, Stat,
+// ReadDir, ReadFile and Glob.
+func (d *FSDelegator) Sub(dir string) (fs.FS, error) {
+	if d.SubFunc == nil {
+		if d.OpenFunc == nil {
+			return nil, &fs.PathError{Op: "sub", Path: dir, Err: ErrNotImplemented}
+		}
+		return fs.Sub(subFS{d}, dir)
+	}
+	return d.SubFunc(dir)
+}
+
+// subFS hides Sub of FSDelegator to use fs.Sub.
+type subFS struct {
+	d *FSDelegator
+}
+
+func (f subFS) Open(name string) (fs.File, error) {
+	return f.d.Open(name)
+}
+
+func (f subFS) Stat(name string) (fs.FileInfo, error) {
+	return f.d.Stat(name)
+}
+
+func (f subFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return f.d.ReadDir(name)
+}
+
+func (f subFS) ReadFile(name string) ([]byte, error) {
+	return f.d.ReadFile(name)
+}
+
+func (f subFS) Glob(pattern string) ([]string, error) {
+	return f.d.Glob(pattern)
+}
+
+// DelegateFS returns a FSDelegator with the provided fs interfaces (fs.FS, fs.StatFS,
+// fs.ReadDirFS, fs.ReadFileFS, fs.GlobFS, fs.SubFS).
+func DelegateFS(fsys fs.FS) *FSDelegator {
+	d := &FSDelegator{
+		OpenFunc: fsys.Open,
+	}
+	if f, ok := fsys.(fs.StatFS); ok {
+		d.StatFunc = f.Stat
+	}
+	if f, ok := fsys.(fs.ReadDirFS); ok {
+		d.ReadDirFunc = f.ReadDir
+	}
+	if f, ok := fsys.(fs.ReadFileFS); ok {
+		d.ReadFileFunc = f.ReadFile
+	}
+	if f, ok := fsys.(fs.GlobFS); ok {
+		d.GlobFunc = f.Glob
+	}
+	if f, ok := fsys.(fs.SubFS); ok {
+		d.SubFunc = f.Sub
+	}
+	return d
+}
+
+// FileDelegator implements fs.File and fs.ReadDirFile. The io methods such as
+// Read, Seek and ReadAt are provided by the embedded Delegator.
+type FileDelegator struct {
+	Delegator
+	StatFunc    func() (fs.FileInfo, error)
+	ReadDirFunc func(n int) ([]fs.DirEntry, error)
+}
+
+var (
+	_ fs.File        = (*FileDelegator)(nil)
+	_ fs.ReadDirFile = (*FileDelegator)(nil)
+)
+
+// Stat calls StatFunc().
+func (d *FileDelegator) Stat() (fs.FileInfo, error) {
+	if d.StatFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return d.StatFunc()
+}
+
+// ReadDir calls ReadDirFunc(n).
+func (d *FileDelegator) ReadDir(n int) ([]fs.DirEntry, error) {
+	if d.ReadDirFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return d.ReadDirFunc(n)
+}
+
+// DelegateFile returns a FileDelegator with the provided fs.File, fs.ReadDirFile and io interfaces.
+func DelegateFile(f fs.File) *FileDelegator {
+	d := &FileDelegator{
+		Delegator: *Delegate(f),
+		StatFunc:  f.Stat,
+	}
+	if r, ok := f.(fs.ReadDirFile); ok {
+		d.ReadDirFunc = r.ReadDir
+	}
+	return d
+}
+
+// FileInfoDelegator implements fs.FileInfo.
+// The methods return the zero values if the functions are nil.
+type FileInfoDelegator struct {
+	NameFunc    func() string
+	SizeFunc    func() int64
+	ModeFunc    func() fs.FileMode
+	ModTimeFunc func() time.Time
+	IsDirFunc   func() bool
+	SysFunc     func() interface{}
+}
+
+var _ fs.FileInfo = (*FileInfoDelegator)(nil)
+
+// Name calls NameFunc().
+func (d *FileInfoDelegator) Name() string {
+	if d.NameFunc == nil {
+		return ""
+	}
+	return d.NameFunc()
+}
+
+// Size calls SizeFunc().
+func (d *FileInfoDelegator) Size() int64 {
+	if d.SizeFunc == nil {
+		return 0
+	}
+	return d.SizeFunc()
+}
+
+// Mode calls ModeFunc().
+func (d *FileInfoDelegator) Mode() fs.FileMode {
+	if d.ModeFunc == nil {
+		return 0
+	}
+	return d.ModeFunc()
+}
+
+// ModTime calls ModTimeFunc().
+func (d *FileInfoDelegator) ModTime() time.Time {
+	if d.ModTimeFunc == nil {
+		return time.Time{}
+	}
+	return d.ModTimeFunc()
+}
+
+// IsDir calls IsDirFunc().
+// If IsDirFunc is nil, IsDir reports whether Mode describes a directory.
+func (d *FileInfoDelegator) IsDir() bool {
+	if d.IsDirFunc == nil {
+		return d.Mode().IsDir()
+	}
+	return d.IsDirFunc()
+}
+
+// Sys calls SysFunc().
+func (d *FileInfoDelegator) Sys() interface{} {
+	if d.SysFunc == nil {
+		return nil
+	}
+	return d.SysFunc()
+}
+
+// DelegateFileInfo returns a FileInfoDelegator with the provided fs.FileInfo.
+func DelegateFileInfo(info fs.FileInfo) *FileInfoDelegator {
+	return &FileInfoDelegator{
+		NameFunc:    info.Name,
+		SizeFunc:    info.Size,
+		ModeFunc:    info.Mode,
+		ModTimeFunc: info.ModTime,
+		IsDirFunc:   info.IsDir,
+		SysFunc:     info.Sys,
+	}
+}
+
+// DirEntryDelegator implements fs.DirEntry.
+type DirEntryDelegator struct {
+	NameFunc  func() string
+	IsDirFunc func() bool
+	TypeFunc  func() fs.FileMode
+	InfoFunc  func() (fs.FileInfo, error)
+}
+
+var _ fs.DirEntry = (*DirEntryDelegator)(nil)
+
+// Name calls NameFunc().
+func (d *DirEntryDelegator) Name() string {
+	if d.NameFunc == nil {
+		return ""
+	}
+	return d.NameFunc()
+}
+
+// IsDir calls IsDirFunc().
+// If IsDirFunc is nil, IsDir reports whether Type describes a directory.
+func (d *DirEntryDelegator) IsDir() bool {
+	if d.IsDirFunc == nil {
+		return d.Type().IsDir()
+	}
+	return d.IsDirFunc()
+}
+
+// Type calls TypeFunc().
+func (d *DirEntryDelegator) Type() fs.FileMode {
+	if d.TypeFunc == nil {
+		return 0
+	}
+	return d.TypeFunc()
+}
+
+// Info calls InfoFunc().
+func (d *DirEntryDelegator) Info() (fs.FileInfo, error) {
+	if d.InfoFunc == nil {
+		return nil, ErrNotImplemented
+	}
+	return d.InfoFunc()
+}
+
+// DelegateDirEntry returns a DirEntryDelegator with the provided fs.DirEntry.
+func DelegateDirEntry(e fs.DirEntry) *DirEntryDelegator {
+	return &DirEntryDelegator{
+		NameFunc:  e.Name,
+		IsDirFunc: e.IsDir,
+		TypeFunc:  e.Type,
+		InfoFunc:  e.Info,
+	}
+}
@@ -0,0 +1,240 @@
+package io2
+
+import (
+	"errors"
+	"io/fs"
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func testFSDelegatorErrors(t *testing.T, d *FSDelegator, wantErr error) {
+	var err error
+	if _, err = d.Open("a"); !errors.Is(err, wantErr) {
+		t.Errorf("unknown: %v", err)
+	}
+	if _, err = d.Stat("a"); !errors.Is(err, wantErr) {
+		t.Errorf("unknown: %v", err)
+	}
+	if _, err = d.ReadDir("a"); !errors.Is(err, wantErr) {
+		t.Errorf("unknown: %v", err)
+	}
+	if _, err = d.ReadFile("a"); !errors.Is(err, wantErr) {
+		t.Errorf("unknown: %v", err)
+	}
+	if _, err = d.Glob("a"); !errors.Is(err, wantErr) {
+		t.Errorf("unknown: %v", err)
+	}
+	if _, err = d.Sub("a"); !errors.Is(err, wantErr) {
+		t.Errorf("unknown: %v", err)
+	}
+}
+
+func TestFSDelegator_ErrNotImplemented(t *testing.T) {
+	testFSDelegatorErrors(t, &FSDelegator{}, ErrNotImplemented)
+}
+
+func TestFSDelegator(t *testing.T) {
+	wantErr := errors.New("test")
+	d := &FSDelegator{
+		OpenFunc: func(_ string) (fs.File, error) {
+			return nil, wantErr
+		},
+		StatFunc: func(_ string) (fs.FileInfo, error) {
+			return nil, wantErr
+		},
+		ReadDirFunc: func(_ string) ([]fs.DirEntry, error) {
+			return nil, wantErr
+		},
+		ReadFileFunc: func(_ string) ([]byte, error) {
+			return nil, wantErr
+		},
+		GlobFunc: func(_ string) ([]string, error) {
+			return nil, wantErr
+		},
+		SubFunc: func(_ string) (fs.FS, error) {
+			return nil, wantErr
+		},
+	}
+	testFSDelegatorErrors(t, d, wantErr)
+	testFSDelegatorErrors(t, DelegateFS(d), wantErr)
+}
+
+func TestFSDelegator_Fallbacks(t *testing.T) {
+	wantErr := errors.New("test")
+	d := &FSDelegator{
+		OpenFunc: func(_ string) (fs.File, error) {
+			return nil, wantErr
+		},
+	}
+	var err error
+	if _, err = d.Stat("a"); !errors.Is(err, wantErr) {
+		t.Errorf("unknown: %v", err)
+	}
+	if _, err = d.ReadDir("a"); !errors.Is(err, wantErr) {
+		t.Errorf("unknown: %v", err)
+	}
+	if _, err = d.ReadFile("a"); !errors.Is(err, wantErr) {
+		t.Errorf("unknown: %v", err)
+	}
+	// NOTE: fs.Glob ignores I/O errors.
+	if matches, err := d.Glob("*"); err != nil || len(matches) != 0 {
+		t.Errorf("unexpected glob %v %v", matches, err)
+	}
+	sub, err := d.Sub("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = sub.Open("b"); !errors.Is(err, wantErr) {
+		t.Errorf("unknown: %v", err)
+	}
+}
+
+func TestDelegateFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.txt":     {Data: []byte("a")},
+		"dir/b.txt": {Data: []byte("b")},
+	}
+	tests := []fs.FS{
+		DelegateFS(fsys),
+		&FSDelegator{OpenFunc: fsys.Open},
+		DelegateFS(struct{ fs.FS }{fsys}),
+	}
+	for i, test := range tests {
+		if err := fstest.TestFS(test, "a.txt", "dir/b.txt"); err != nil {
+			t.Errorf("tests[%d] %v", i, err)
+		}
+	}
+}
+
+func TestDelegateFS_Override(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.txt": {Data: []byte("a")},
+	}
+	d := DelegateFS(fsys)
+	d.ReadFileFunc = nil
+	d.OpenFunc = func(name string) (fs.File, error) {
+		f, err := fsys.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		fd := DelegateFile(f)
+		fd.ReadFunc = func(_ []byte) (int, error) {
+			return 0, fs.ErrPermission
+		}
+		return fd, nil
+	}
+	if _, err := d.ReadFile("a.txt"); !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("error %v; want %v", err, fs.ErrPermission)
+	}
+}
+
+func TestDelegateFS_SubOverride(t *testing.T) {
+	fsys := fstest.MapFS{
+		"dir/a.txt": {Data: []byte("a")},
+	}
+	d := DelegateFS(fsys)
+	d.SubFunc = nil
+	d.ReadDirFunc = func(_ string) ([]fs.DirEntry, error) {
+		return nil, fs.ErrPermission
+	}
+	d.ReadFileFunc = func(_ string) ([]byte, error) {
+		return nil, fs.ErrPermission
+	}
+	sub, err := d.Sub("dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fs.ReadDir(sub, "."); !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("error %v; want %v", err, fs.ErrPermission)
+	}
+	if _, err := fs.ReadFile(sub, "a.txt"); !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("error %v; want %v", err, fs.ErrPermission)
+	}
+
+	sub, err = (&FSDelegator{OpenFunc: fsys.Open}).Sub("dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fstest.TestFS(sub, "a.txt"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFileDelegator(t *testing.T) {
+	d := &FileDelegator{}
+	if _, err := d.Stat(); !errors.Is(err, ErrNotImplemented) {
+		t.Errorf("unknown: %v", err)
+	}
+	if _, err := d.ReadDir(-1); !errors.Is(err, ErrNotImplemented) {
+		t.Errorf("unknown: %v", err)
+	}
+	if _, err := d.Read(nil); !errors.Is(err, ErrNotImplemented) {
+		t.Errorf("unknown: %v", err)
+	}
+
+	f, err := fstest.MapFS{"dir/a.txt": {}}.Open("dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd := DelegateFile(f)
+	entries, err := fd.ReadDir(-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "a.txt" {
+		t.Errorf("unexpected entries %v", entries)
+	}
+}
+
+func TestFileInfoDelegator(t *testing.T) {
+	zero := &FileInfoDelegator{}
+	if zero.Name() != "" || zero.Size() != 0 || zero.Mode() != 0 ||
+		!zero.ModTime().IsZero() || zero.IsDir() || zero.Sys() != nil {
+		t.Errorf("unexpected zero values")
+	}
+
+	info, err := fs.Stat(fstest.MapFS{"a.txt": {Data: []byte("a"), Mode: 0644}}, "a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := DelegateFileInfo(info)
+	d.SizeFunc = func() int64 {
+		return 1 << 40
+	}
+	d.ModeFunc = func() fs.FileMode {
+		return fs.ModeDir | 0700
+	}
+	d.IsDirFunc = nil
+	got := []interface{}{d.Name(), d.Size(), d.Mode(), d.IsDir(), d.ModTime(), d.Sys()}
+	want := []interface{}{"a.txt", int64(1 << 40), fs.ModeDir | 0700, true, time.Time{}, nil}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestDirEntryDelegator(t *testing.T) {
+	zero := &DirEntryDelegator{}
+	if zero.Name() != "" || zero.IsDir() || zero.Type() != 0 {
+		t.Errorf("unexpected zero values")
+	}
+	if _, err := zero.Info(); !errors.Is(err, ErrNotImplemented) {
+		t.Errorf("unknown: %v", err)
+	}
+
+	entries, err := fs.ReadDir(fstest.MapFS{"dir/a.txt": {}}, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := DelegateDirEntry(entries[0])
+	d.InfoFunc = func() (fs.FileInfo, error) {
+		return nil, fs.ErrPermission
+	}
+	if d.Name() != "dir" || !d.IsDir() || d.Type() != fs.ModeDir {
+		t.Errorf("unexpected entry %s %v %v", d.Name(), d.IsDir(), d.Type())
+	}
+	if _, err := d.Info(); !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("error %v; want %v", err, fs.ErrPermission)
+	}
+}
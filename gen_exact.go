@@ -0,0 +1,77 @@
+//go:build ignore
+// +build ignore
+
+// This program generates exact.go. Invoke it by go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+)
+
+var interfaces = []string{
+	"Reader",
+	"Writer",
+	"Seeker",
+	"Closer",
+	"ReaderAt",
+	"WriterAt",
+	"ReaderFrom",
+	"WriterTo",
+}
+
+func main() {
+	buf := &bytes.Buffer{}
+	fmt.Fprint(buf, `// Code generated by "go run gen_exact.go"; DO NOT EDIT.
+
+package io2
+
+import (
+	"io"
+)
+
+// WrapDelegator returns a value that calls the functions of d and implements
+// only the io interfaces (io.Reader, io.Writer, io.Seeker, io.Closer,
+// io.ReaderAt, io.WriterAt, io.ReaderFrom, io.WriterTo) implemented by i.
+// The returned value never implements io.ByteReader, io.ByteScanner,
+// io.ByteWriter, io.RuneReader, io.RuneScanner or io.StringWriter, even if i does.
+// The returned value also implements DelegatorUnwrapper.
+func WrapDelegator(d *Delegator, i interface{}) interface{} {
+	u := delegatorUnwrapper{d: d}
+	mask := 0
+`)
+	for bit, name := range interfaces {
+		fmt.Fprintf(buf, "\tif _, ok := i.(io.%s); ok {\n\t\tmask |= %d\n\t}\n", name, 1<<bit)
+	}
+	fmt.Fprint(buf, "\tswitch mask {\n")
+	for mask := 0; mask < 1<<len(interfaces); mask++ {
+		var names []string
+		for bit, name := range interfaces {
+			if mask&(1<<bit) != 0 {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			fmt.Fprintf(buf, "\tcase %d:\n\t\treturn struct {\n\t\t\tDelegatorUnwrapper\n\t\t}{u}\n", mask)
+			continue
+		}
+		fmt.Fprintf(buf, "\tcase %d: // %s\n\t\treturn struct {\n\t\t\tDelegatorUnwrapper\n", mask, strings.Join(names, ", "))
+		for _, name := range names {
+			fmt.Fprintf(buf, "\t\t\tio.%s\n", name)
+		}
+		fmt.Fprintf(buf, "\t\t}{u%s}\n", strings.Repeat(", d", len(names)))
+	}
+	fmt.Fprint(buf, "\t}\n\tpanic(\"unreachable\")\n}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("exact.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -10,6 +10,16 @@ import (
 var (
 	// ErrNotImplemented "not implemented"
 	ErrNotImplemented = errors.New("not implemented")
+	// ErrNoUndo "nothing to undo"
+	ErrNoUndo = errors.New("nothing to undo")
+	// ErrNoRedo "nothing to redo"
+	ErrNoRedo = errors.New("nothing to redo")
+	// ErrNoCheckpoint "no such checkpoint"
+	ErrNoCheckpoint = errors.New("no such checkpoint")
+	// ErrTooLarge "too large"
+	ErrTooLarge = errors.New("too large")
+	// ErrEvicted "offset evicted from the ring buffer"
+	ErrEvicted = errors.New("offset evicted from the ring buffer")
 )
 
 var osOpen = func(filename string) (*os.File, error) {
@@ -19,3 +29,7 @@ var osOpen = func(filename string) (*os.File, error) {
 var fsStat = func(file *os.File) (fs.FileInfo, error) {
 	return file.Stat()
 }
+
+var osStat = func(filename string) (fs.FileInfo, error) {
+	return os.Stat(filename)
+}
@@ -0,0 +1,429 @@
+// Package io2test implements tests for the io interfaces against the
+// contracts documented in the io package.
+//
+// Each TestXxx function receives a factory that returns a new value for
+// each check, and reports the violations as subtests of t. The factory is
+// called with the *testing.T of the subtest, so it can call t.Fatal and
+// t.Cleanup.
+package io2test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"testing/iotest"
+)
+
+// maxConsecutiveEmptyReads is the number of consecutive 0, nil reads that
+// are considered no progress, same as bufio.
+const maxConsecutiveEmptyReads = 100
+
+func readAll(r io.Reader, size int) ([]byte, error) {
+	var got []byte
+	p := make([]byte, size)
+	empty := 0
+	for {
+		n, err := r.Read(p)
+		if n < 0 || n > len(p) {
+			return got, fmt.Errorf("Read returns n %d out of range [0, %d]", n, len(p))
+		}
+		got = append(got, p[:n]...)
+		if err == io.EOF {
+			return got, nil
+		}
+		if err != nil {
+			return got, err
+		}
+		if n == 0 {
+			empty++
+			if empty >= maxConsecutiveEmptyReads {
+				return got, io.ErrNoProgress
+			}
+			continue
+		}
+		empty = 0
+	}
+}
+
+// TestReader tests that the reader returned by newReader reads content.
+// The reader is also tested by testing/iotest.TestReader.
+func TestReader(t *testing.T, newReader func(t *testing.T) io.Reader, content []byte) {
+	t.Run("ReadAll", func(t *testing.T) {
+		for _, size := range []int{1, 3, len(content) + 1} {
+			got, err := readAll(newReader(t), size)
+			if err != nil {
+				t.Fatalf("read with %d bytes buffer: %v", size, err)
+			}
+			if !bytes.Equal(got, content) {
+				t.Errorf("read with %d bytes buffer %q; want %q", size, got, content)
+			}
+		}
+	})
+	t.Run("EOF", func(t *testing.T) {
+		r := newReader(t)
+		if _, err := readAll(r, len(content)+1); err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 2; i++ {
+			n, err := r.Read(make([]byte, 1))
+			if n != 0 || err != io.EOF {
+				t.Errorf("Read after EOF returns %d, %v; want 0, EOF", n, err)
+			}
+		}
+	})
+	t.Run("ZeroLength", func(t *testing.T) {
+		r := newReader(t)
+		n, err := r.Read([]byte{})
+		if n != 0 {
+			t.Errorf("Read of empty buffer returns %d", n)
+		}
+		if err != nil && err != io.EOF {
+			t.Errorf("Read of empty buffer returns %v", err)
+		}
+		got, err := readAll(r, len(content)+1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, content) {
+			t.Errorf("read after empty read %q; want %q", got, content)
+		}
+	})
+	t.Run("iotest", func(t *testing.T) {
+		if err := iotest.TestReader(newReader(t), content); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func tell(s io.Seeker) (int64, error) {
+	return s.Seek(0, io.SeekCurrent)
+}
+
+// TestSeeker tests that the seeker returned by newSeeker seeks content.
+// If the seeker implements io.Reader, the read bytes are also tested.
+func TestSeeker(t *testing.T, newSeeker func(t *testing.T) io.Seeker, content []byte) {
+	testSeeker(t, newSeeker, content, false)
+}
+
+// TestClampingSeeker is like TestSeeker for the seekers that set the offset
+// to 0 instead of returning an error when seeking to a negative offset, like
+// io2.WriteSeekBuffer. Seek with an invalid whence is not tested.
+func TestClampingSeeker(t *testing.T, newSeeker func(t *testing.T) io.Seeker, content []byte) {
+	testSeeker(t, newSeeker, content, true)
+}
+
+func testSeeker(t *testing.T, newSeeker func(t *testing.T) io.Seeker, content []byte, clamp bool) {
+	size := int64(len(content))
+	checkRead := func(t *testing.T, s io.Seeker, off int64) {
+		r, ok := s.(io.Reader)
+		if !ok {
+			return
+		}
+		want := []byte{}
+		if off < size {
+			want = content[off:]
+		}
+		got, err := readAll(r, len(content)+1)
+		if err != nil {
+			t.Fatalf("read at %d: %v", off, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("read at %d %q; want %q", off, got, want)
+		}
+	}
+
+	t.Run("Whence", func(t *testing.T) {
+		for off := int64(0); off <= size; off++ {
+			tests := []struct {
+				name   string
+				prev   int64
+				offset int64
+				whence int
+			}{
+				{name: "SeekStart", offset: off, whence: io.SeekStart},
+				{name: "SeekCurrent", offset: off, whence: io.SeekCurrent},
+				{name: "SeekCurrent", prev: size, offset: off - size, whence: io.SeekCurrent},
+				{name: "SeekEnd", offset: off - size, whence: io.SeekEnd},
+			}
+			for _, test := range tests {
+				s := newSeeker(t)
+				if test.prev != 0 {
+					if _, err := s.Seek(test.prev, io.SeekStart); err != nil {
+						t.Fatal(err)
+					}
+				}
+				n, err := s.Seek(test.offset, test.whence)
+				if err != nil {
+					t.Fatalf("%s(%d) from %d: %v", test.name, test.offset, test.prev, err)
+				}
+				if n != off {
+					t.Errorf("%s(%d) from %d returns %d; want %d", test.name, test.offset, test.prev, n, off)
+				}
+				if cur, err := tell(s); err != nil || cur != off {
+					t.Errorf("%s(%d) from %d then current offset %d, %v; want %d", test.name, test.offset, test.prev, cur, err, off)
+				}
+				checkRead(t, s, off)
+			}
+		}
+	})
+	t.Run("Negative", func(t *testing.T) {
+		tests := []struct {
+			offset int64
+			whence int
+		}{
+			{offset: -1, whence: io.SeekStart},
+			{offset: -2, whence: io.SeekCurrent},
+			{offset: -size - 1, whence: io.SeekEnd},
+		}
+		for _, test := range tests {
+			s := newSeeker(t)
+			if _, err := s.Seek(1, io.SeekStart); err != nil {
+				t.Fatal(err)
+			}
+			if clamp {
+				n, err := s.Seek(test.offset, test.whence)
+				if err != nil || n != 0 {
+					t.Errorf("Seek(%d, %d) to a negative offset returns %d, %v; want 0", test.offset, test.whence, n, err)
+				}
+				checkRead(t, s, 0)
+				continue
+			}
+			if _, err := s.Seek(test.offset, test.whence); err == nil {
+				t.Errorf("Seek(%d, %d) to a negative offset returns no error", test.offset, test.whence)
+			}
+			if cur, err := tell(s); err != nil || cur != 1 {
+				t.Errorf("Seek(%d, %d) to a negative offset changes the offset to %d, %v; want 1", test.offset, test.whence, cur, err)
+			}
+			checkRead(t, s, 1)
+		}
+	})
+	t.Run("PastEnd", func(t *testing.T) {
+		s := newSeeker(t)
+		n, err := s.Seek(size+10, io.SeekStart)
+		if err != nil {
+			t.Fatalf("Seek past end: %v", err)
+		}
+		if n != size+10 {
+			t.Errorf("Seek past end returns %d; want %d", n, size+10)
+		}
+		checkRead(t, s, size+10)
+	})
+	t.Run("InvalidWhence", func(t *testing.T) {
+		if clamp {
+			t.Skip("clamping seeker")
+		}
+		s := newSeeker(t)
+		if _, err := s.Seek(0, -1); err == nil {
+			t.Errorf("Seek with invalid whence returns no error")
+		}
+	})
+}
+
+// TestReaderAt tests that the reader returned by newReaderAt reads content
+// at any offset, and does not affect the offset of io.Seeker.
+func TestReaderAt(t *testing.T, newReaderAt func(t *testing.T) io.ReaderAt, content []byte) {
+	size := int64(len(content))
+	t.Run("Offsets", func(t *testing.T) {
+		r := newReaderAt(t)
+		for off := int64(0); off <= size; off++ {
+			for l := 0; l <= len(content)-int(off)+1; l++ {
+				p := make([]byte, l)
+				n, err := r.ReadAt(p, off)
+				want := content[off:]
+				if len(want) > l {
+					want = want[:l]
+				}
+				if n != len(want) {
+					t.Errorf("ReadAt(%d bytes, %d) returns %d; want %d", l, off, n, len(want))
+				}
+				if n < len(p) && err == nil {
+					t.Errorf("ReadAt(%d bytes, %d) returns %d without an error", l, off, n)
+				}
+				if err != nil && err != io.EOF {
+					t.Errorf("ReadAt(%d bytes, %d) returns %v", l, off, err)
+				}
+				if n >= 0 && n <= len(p) && !bytes.Equal(p[:n], want[:n]) {
+					t.Errorf("ReadAt(%d bytes, %d) reads %q; want %q", l, off, p[:n], want)
+				}
+			}
+		}
+	})
+	t.Run("EOF", func(t *testing.T) {
+		r := newReaderAt(t)
+		n, err := r.ReadAt(make([]byte, 1), size)
+		if n != 0 || err != io.EOF {
+			t.Errorf("ReadAt(1 byte, %d) returns %d, %v; want 0, EOF", size, n, err)
+		}
+		n, err = r.ReadAt(make([]byte, 1), size+10)
+		if n != 0 || err == nil {
+			t.Errorf("ReadAt(1 byte, %d) returns %d, %v; want 0 and an error", size+10, n, err)
+		}
+	})
+	t.Run("Negative", func(t *testing.T) {
+		r := newReaderAt(t)
+		if _, err := r.ReadAt(make([]byte, 1), -1); err == nil {
+			t.Errorf("ReadAt at a negative offset returns no error")
+		}
+	})
+	t.Run("Offset", func(t *testing.T) {
+		r := newReaderAt(t)
+		s, ok := r.(io.Seeker)
+		if !ok {
+			t.Skip("not io.Seeker")
+		}
+		if _, err := s.Seek(1, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := r.ReadAt(make([]byte, len(content)), 0); err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+		if cur, err := tell(s); err != nil || cur != 1 {
+			t.Errorf("ReadAt changes the offset to %d, %v; want 1", cur, err)
+		}
+	})
+	t.Run("Parallel", func(t *testing.T) {
+		r := newReaderAt(t)
+		errs := make(chan error, len(content))
+		wg := &sync.WaitGroup{}
+		for off := 0; off < len(content); off++ {
+			wg.Add(1)
+			go func(off int) {
+				defer wg.Done()
+				p := make([]byte, len(content)-off)
+				n, err := r.ReadAt(p, int64(off))
+				if err != nil && err != io.EOF {
+					errs <- err
+					return
+				}
+				if !bytes.Equal(p[:n], content[off:]) {
+					errs <- fmt.Errorf("parallel ReadAt(%d) reads %q; want %q", off, p[:n], content[off:])
+				}
+			}(off)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			t.Error(err)
+		}
+	})
+}
+
+var chunks = [][]byte{
+	[]byte("abc"),
+	{},
+	[]byte("d"),
+	[]byte("efghij"),
+}
+
+// TestWriter tests that the writer returned by newWriter writes all bytes
+// and does not retain the written buffers. contents returns the written
+// bytes of the writer.
+func TestWriter(t *testing.T, newWriter func(t *testing.T) io.Writer, contents func(t *testing.T, w io.Writer) []byte) {
+	t.Run("Write", func(t *testing.T) {
+		w := newWriter(t)
+		var want []byte
+		for _, chunk := range chunks {
+			p := append([]byte(nil), chunk...)
+			n, err := w.Write(p)
+			if err != nil {
+				t.Fatalf("Write(%q): %v", chunk, err)
+			}
+			if n != len(p) {
+				t.Errorf("Write(%q) returns %d; want %d", chunk, n, len(p))
+			}
+			for i := range p {
+				p[i] = '!'
+			}
+			want = append(want, chunk...)
+		}
+		if got := contents(t, w); !bytes.Equal(got, want) {
+			t.Errorf("written %q; want %q", got, want)
+		}
+	})
+}
+
+// TestWriterAt tests that the writer returned by newWriterAt writes all
+// bytes at any offset, does not retain the written buffers and does not
+// affect the offset of io.Seeker. contents returns the written bytes of
+// the writer.
+func TestWriterAt(t *testing.T, newWriterAt func(t *testing.T) io.WriterAt, contents func(t *testing.T, w io.WriterAt) []byte) {
+	t.Run("WriteAt", func(t *testing.T) {
+		w := newWriterAt(t)
+		var want []byte
+		for _, chunk := range chunks {
+			want = append(want, chunk...)
+		}
+		off := len(want)
+		for i := len(chunks) - 1; i >= 0; i-- {
+			chunk := chunks[i]
+			off -= len(chunk)
+			p := append([]byte(nil), chunk...)
+			n, err := w.WriteAt(p, int64(off))
+			if err != nil {
+				t.Fatalf("WriteAt(%q, %d): %v", chunk, off, err)
+			}
+			if n != len(p) {
+				t.Errorf("WriteAt(%q, %d) returns %d; want %d", chunk, off, n, len(p))
+			}
+			for i := range p {
+				p[i] = '!'
+			}
+		}
+		if got := contents(t, w); !bytes.Equal(got, want) {
+			t.Errorf("written %q; want %q", got, want)
+		}
+	})
+	t.Run("Negative", func(t *testing.T) {
+		w := newWriterAt(t)
+		if _, err := w.WriteAt([]byte("a"), -1); err == nil {
+			t.Errorf("WriteAt at a negative offset returns no error")
+		}
+	})
+	t.Run("Offset", func(t *testing.T) {
+		w := newWriterAt(t)
+		s, ok := w.(io.Seeker)
+		if !ok {
+			t.Skip("not io.Seeker")
+		}
+		if _, err := w.WriteAt([]byte("abc"), 0); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.Seek(1, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.WriteAt([]byte("de"), 3); err != nil {
+			t.Fatal(err)
+		}
+		if cur, err := tell(s); err != nil || cur != 1 {
+			t.Errorf("WriteAt changes the offset to %d, %v; want 1", cur, err)
+		}
+	})
+}
+
+// TestCloser tests that the closer returned by newCloser can be closed,
+// and that closing it again does not panic. If idempotent is true, the
+// second Close must also return no error.
+func TestCloser(t *testing.T, newCloser func(t *testing.T) io.Closer, idempotent bool) {
+	t.Run("Close", func(t *testing.T) {
+		c := newCloser(t)
+		if err := c.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+		var err error
+		panicked := false
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					panicked = true
+					t.Errorf("second Close panics: %v", r)
+				}
+			}()
+			err = c.Close()
+		}()
+		if !panicked && idempotent && err != nil {
+			t.Errorf("second Close: %v", err)
+		}
+	})
+}
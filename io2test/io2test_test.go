@@ -0,0 +1,64 @@
+package io2test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const content = "Hello, World!"
+
+func TestStrings(t *testing.T) {
+	TestReader(t, func(t *testing.T) io.Reader { return strings.NewReader(content) }, []byte(content))
+	TestSeeker(t, func(t *testing.T) io.Seeker { return strings.NewReader(content) }, []byte(content))
+	TestReaderAt(t, func(t *testing.T) io.ReaderAt { return strings.NewReader(content) }, []byte(content))
+}
+
+func TestBuffer(t *testing.T) {
+	TestReader(t, func(t *testing.T) io.Reader { return bytes.NewBufferString(content) }, []byte(content))
+	TestWriter(t, func(t *testing.T) io.Writer { return &bytes.Buffer{} }, func(t *testing.T, w io.Writer) []byte {
+		return w.(*bytes.Buffer).Bytes()
+	})
+}
+
+func TestFile(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "content.txt")
+	if err := ioutil.WriteFile(name, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	open := func(t *testing.T) *os.File {
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { f.Close() })
+		return f
+	}
+	create := func(t *testing.T) *os.File {
+		f, err := ioutil.TempFile(dir, "*.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { f.Close() })
+		return f
+	}
+	contents := func(t *testing.T, w interface{}) []byte {
+		p, err := ioutil.ReadFile(w.(*os.File).Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	TestReader(t, func(t *testing.T) io.Reader { return open(t) }, []byte(content))
+	TestSeeker(t, func(t *testing.T) io.Seeker { return open(t) }, []byte(content))
+	TestReaderAt(t, func(t *testing.T) io.ReaderAt { return open(t) }, []byte(content))
+	TestWriter(t, func(t *testing.T) io.Writer { return create(t) }, func(t *testing.T, w io.Writer) []byte { return contents(t, w) })
+	TestWriterAt(t, func(t *testing.T) io.WriterAt { return create(t) }, func(t *testing.T, w io.WriterAt) []byte { return contents(t, w) })
+	TestCloser(t, func(t *testing.T) io.Closer { return open(t) }, false)
+}
@@ -0,0 +1,210 @@
+package io2
+
+import (
+	"io"
+	"io/ioutil"
+)
+
+// Journal is a WriteSeekBuffer that records every change as a reversible
+// operation. Only the written bytes and the bytes they replace are kept, not
+// copies of the whole buffer.
+type Journal struct {
+	*WriteSeekBuffer
+	ops         []journalOp
+	pos         int
+	checkpoints map[string]int
+}
+
+// journalOp is a change from the state (oldLen, oldOff) to (newLen, newOff).
+// data is written at off and replaces old.
+type journalOp struct {
+	off            int
+	old, data      []byte
+	oldLen, newLen int
+	oldOff, newOff int
+}
+
+var _ WriteSeekCloser = (*Journal)(nil)
+
+// NewJournal returns a Journal that records the changes to b.
+func NewJournal(b *WriteSeekBuffer) *Journal {
+	return &Journal{
+		WriteSeekBuffer: b,
+		checkpoints:     map[string]int{},
+	}
+}
+
+func (j *Journal) record(op journalOp) {
+	j.ops = append(j.ops[:j.pos], op)
+	for name, pos := range j.checkpoints {
+		if pos > j.pos {
+			delete(j.checkpoints, name)
+		}
+	}
+	j.pos++
+}
+
+func (j *Journal) old(off, n int) []byte {
+	if off >= j.len {
+		return nil
+	}
+	if off+n > j.len {
+		n = j.len - off
+	}
+	old := make([]byte, n)
+	j.readAt(old, off)
+	return old
+}
+
+func (j *Journal) writeAt(p []byte, off int) (int, error) {
+	op := journalOp{
+		off:    off,
+		old:    j.old(off, len(p)),
+		oldLen: j.len,
+		oldOff: j.off,
+	}
+	n, err := j.WriteSeekBuffer.writeAt(p, off)
+	if n > 0 {
+		// Record only the bytes written, which may be cut by the maximum size.
+		op.data = append([]byte{}, p[:n]...)
+		if len(op.old) > n {
+			op.old = op.old[:n]
+		}
+		op.newLen = j.len
+		op.newOff = j.off
+		j.record(op)
+	}
+	return n, err
+}
+
+// Write writes the contents of p to the offset and records the change.
+func (j *Journal) Write(p []byte) (int, error) {
+	n, err := j.writeAt(p, j.off)
+	if n > 0 {
+		j.off += n
+		j.ops[j.pos-1].newOff = j.off
+	}
+	return n, err
+}
+
+// WriteAt writes the contents of p to the offset off and records the change.
+func (j *Journal) WriteAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return j.WriteSeekBuffer.WriteAt(p, off)
+	}
+	return j.writeAt(p, int(off))
+}
+
+// ReadFrom reads data from r until EOF and writes it to the offset as one change.
+func (j *Journal) ReadFrom(r io.Reader) (int64, error) {
+	p, err := ioutil.ReadAll(r)
+	n, werr := j.Write(p)
+	if err == nil {
+		err = werr
+	}
+	return int64(n), err
+}
+
+// Truncate changes the size of the buffer with offset and records the change.
+func (j *Journal) Truncate(n int) {
+	op := journalOp{
+		oldLen: j.len,
+		oldOff: j.off,
+	}
+	if n < 0 {
+		n = j.off + n
+	}
+	if n < 0 {
+		n = 0
+	}
+	if n < j.len {
+		op.off = n
+		op.old = j.old(n, j.len-n)
+	}
+	j.WriteSeekBuffer.Truncate(n)
+	op.newLen = j.len
+	op.newOff = j.off
+	if op.newLen != op.oldLen {
+		j.record(op)
+	}
+}
+
+// Close clears the journal and closes the buffer.
+func (j *Journal) Close() error {
+	j.ops, j.pos = nil, 0
+	j.checkpoints = map[string]int{}
+	return j.WriteSeekBuffer.Close()
+}
+
+func (j *Journal) apply(off int, data []byte, n, noff int) error {
+	b := j.WriteSeekBuffer
+	if data != nil {
+		if _, err := b.writeAt(data, off); err != nil {
+			return err
+		}
+	}
+	if n != b.len {
+		b.Truncate(n)
+	}
+	b.off = noff
+	return nil
+}
+
+// Undo reverts the last change, restoring the contents, the length and the
+// offset before the change. Seek is not a change, so the offset set by Seek
+// after the change is not kept.
+// If there is no change to revert, Undo returns ErrNoUndo.
+func (j *Journal) Undo() error {
+	if j.pos == 0 {
+		return ErrNoUndo
+	}
+	op := j.ops[j.pos-1]
+	if err := j.apply(op.off, op.old, op.oldLen, op.oldOff); err != nil {
+		return err
+	}
+	j.pos--
+	return nil
+}
+
+// Redo applies the last reverted change again, restoring the contents, the
+// length and the offset after the change. If there is no change to apply,
+// Redo returns ErrNoRedo. Any change other than Undo and Redo clears the changes
+// to redo.
+func (j *Journal) Redo() error {
+	if j.pos == len(j.ops) {
+		return ErrNoRedo
+	}
+	op := j.ops[j.pos]
+	if err := j.apply(op.off, op.data, op.newLen, op.newOff); err != nil {
+		return err
+	}
+	j.pos++
+	return nil
+}
+
+// Checkpoint names the current state. The name replaces an existing checkpoint
+// of the same name.
+func (j *Journal) Checkpoint(name string) {
+	j.checkpoints[name] = j.pos
+}
+
+// RollbackTo undoes or redoes changes until the state named by Checkpoint.
+// If the checkpoint does not exist or was discarded by a change after Undo,
+// RollbackTo returns ErrNoCheckpoint.
+func (j *Journal) RollbackTo(name string) error {
+	pos, ok := j.checkpoints[name]
+	if !ok {
+		return ErrNoCheckpoint
+	}
+	for j.pos > pos {
+		if err := j.Undo(); err != nil {
+			return err
+		}
+	}
+	for j.pos < pos {
+		if err := j.Redo(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package io2
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestJournal_TruncateExtend(t *testing.T) {
+	j := NewJournal(NewWriteSeekBufferBytes([]byte(`123`)))
+	defer j.Close()
+
+	j.Truncate(5)
+	if got, want := string(j.Bytes()), "123\x00\x00"; got != want {
+		t.Errorf("bytes %q; want %q", got, want)
+	}
+	if err := j.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(j.Bytes()), "123"; got != want || j.Offset() != 3 {
+		t.Errorf("undo bytes %q off %d; want %q %d", got, j.Offset(), want, 3)
+	}
+	if err := j.Redo(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(j.Bytes()), "123\x00\x00"; got != want || j.Offset() != 5 {
+		t.Errorf("redo bytes %q off %d; want %q %d", got, j.Offset(), want, 5)
+	}
+}
+
+func TestJournal(t *testing.T) {
+	j := NewJournal(NewWriteSeekBufferBytes([]byte(`123456789`)))
+	defer j.Close()
+
+	j.Seek(2, io.SeekStart)
+	j.Write([]byte(`ab`))
+	j.Truncate(6)
+	j.WriteAt([]byte(`xyz`), 8)
+	j.ReadFrom(strings.NewReader(`AB`))
+
+	states := []struct {
+		bytes string
+		off   int
+	}{
+		{bytes: "123456789", off: 2},
+		{bytes: "12ab56789", off: 4},
+		{bytes: "12ab56", off: 6},
+		{bytes: "12ab56\x00\x00xyz", off: 6},
+		{bytes: "12ab56ABxyz", off: 8},
+	}
+	check := func(i int) {
+		t.Helper()
+		want := states[i]
+		if got := string(j.Bytes()); got != want.bytes {
+			t.Errorf("states[%d] bytes %q; want %q", i, got, want.bytes)
+		}
+		if j.Offset() != want.off {
+			t.Errorf("states[%d] off %d; want %d", i, j.Offset(), want.off)
+		}
+	}
+
+	for i := len(states) - 1; i > 0; i-- {
+		check(i)
+		if err := j.Undo(); err != nil {
+			t.Fatalf("states[%d] undo: %v", i, err)
+		}
+	}
+	check(0)
+	if err := j.Undo(); !errors.Is(err, ErrNoUndo) {
+		t.Errorf("undo error %v; want %v", err, ErrNoUndo)
+	}
+
+	for i := 1; i < len(states); i++ {
+		if err := j.Redo(); err != nil {
+			t.Fatalf("states[%d] redo: %v", i, err)
+		}
+		check(i)
+	}
+	if err := j.Redo(); !errors.Is(err, ErrNoRedo) {
+		t.Errorf("redo error %v; want %v", err, ErrNoRedo)
+	}
+}
+
+func TestJournal_Checkpoint(t *testing.T) {
+	j := NewJournal(NewPagedWriteSeekBuffer(4))
+	defer j.Close()
+
+	j.Write([]byte(`header`))
+	j.Checkpoint("header")
+	j.Write([]byte(`index`))
+	j.Checkpoint("index")
+	j.WriteAt([]byte(`HEAD`), 0)
+
+	if err := j.RollbackTo("header"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(j.Bytes()), "header"; got != want {
+		t.Errorf("bytes %s; want %s", got, want)
+	}
+	if err := j.RollbackTo("index"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(j.Bytes()), "headerindex"; got != want {
+		t.Errorf("bytes %s; want %s", got, want)
+	}
+
+	j.Undo()
+	j.Write([]byte(`table`))
+	if err := j.RollbackTo("index"); !errors.Is(err, ErrNoCheckpoint) {
+		t.Errorf("rollback error %v; want %v", err, ErrNoCheckpoint)
+	}
+	if err := j.RollbackTo("header"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(j.Bytes()), "header"; got != want {
+		t.Errorf("bytes %s; want %s", got, want)
+	}
+}
+
+func TestJournal_MaxSize(t *testing.T) {
+	b := NewWriteSeekBufferBytes([]byte(`12`))
+	b.SetMaxSize(4)
+	j := NewJournal(b)
+	defer j.Close()
+
+	n, err := j.WriteAt([]byte(`abcdef`), 1)
+	if !errors.Is(err, ErrTooLarge) || n != 3 {
+		t.Fatalf("write at %d %v; want %d %v", n, err, 3, ErrTooLarge)
+	}
+	if err := j.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(j.Bytes()), "12"; got != want {
+		t.Errorf("undo bytes %s; want %s", got, want)
+	}
+	if err := j.Redo(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(j.Bytes()), "1abc"; got != want {
+		t.Errorf("redo bytes %s; want %s", got, want)
+	}
+	if err := j.Redo(); !errors.Is(err, ErrNoRedo) {
+		t.Errorf("redo error %v; want %v", err, ErrNoRedo)
+	}
+}
@@ -0,0 +1,569 @@
+package io2
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemFS implements WritableFS using in-memory files. The contents of the
+// files are stored in WriteSeekBuffer. MemFS is safe for concurrent use.
+type MemFS struct {
+	mu   sync.RWMutex
+	root *memNode
+	now  func() time.Time
+}
+
+var (
+	_ WritableFS    = (*MemFS)(nil)
+	_ fs.StatFS     = (*MemFS)(nil)
+	_ fs.ReadDirFS  = (*MemFS)(nil)
+	_ fs.ReadFileFS = (*MemFS)(nil)
+)
+
+type memNode struct {
+	name     string
+	mode     fs.FileMode
+	modTime  time.Time
+	buf      *WriteSeekBuffer
+	children map[string]*memNode
+}
+
+// NewMemFS returns an empty MemFS.
+func NewMemFS() *MemFS {
+	fsys := &MemFS{now: time.Now}
+	fsys.root = &memNode{
+		name:     ".",
+		mode:     fs.ModeDir | 0777,
+		modTime:  fsys.now(),
+		children: map[string]*memNode{},
+	}
+	return fsys
+}
+
+func (n *memNode) info() *memFileInfo {
+	size := int64(0)
+	if n.buf != nil {
+		size = int64(n.buf.Len())
+	}
+	return &memFileInfo{name: n.name, size: size, mode: n.mode, modTime: n.modTime}
+}
+
+func (n *memNode) entries() []fs.DirEntry {
+	entries := make([]fs.DirEntry, 0, len(n.children))
+	for _, c := range n.children {
+		entries = append(entries, memDirEntry{c.info()})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	return entries
+}
+
+// lookup returns the node of name. fsys.mu must be held.
+func (fsys *MemFS) lookup(op, name string) (*memNode, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	n := fsys.root
+	if name == "." {
+		return n, nil
+	}
+	for _, elem := range strings.Split(name, "/") {
+		if n.children == nil {
+			return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		c, ok := n.children[elem]
+		if !ok {
+			return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		n = c
+	}
+	return n, nil
+}
+
+// lookupParent returns the parent directory node of name. fsys.mu must be held.
+func (fsys *MemFS) lookupParent(op, name string) (*memNode, string, error) {
+	if !fs.ValidPath(name) || name == "." {
+		return nil, "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	dir, elem := path.Split(name)
+	if dir == "" {
+		dir = "."
+	} else {
+		dir = dir[:len(dir)-1]
+	}
+	parent, err := fsys.lookup(op, dir)
+	if err != nil {
+		return nil, "", &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	if parent.children == nil {
+		return nil, "", &fs.PathError{Op: op, Path: name, Err: errors.New("not a directory")}
+	}
+	return parent, elem, nil
+}
+
+// Open opens the named file for reading.
+func (fsys *MemFS) Open(name string) (fs.File, error) {
+	fsys.mu.RLock()
+	defer fsys.mu.RUnlock()
+	n, err := fsys.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+	return &memFile{fsys: fsys, node: n, name: name, flag: os.O_RDONLY}, nil
+}
+
+// Stat returns a FileInfo describing the named file.
+func (fsys *MemFS) Stat(name string) (fs.FileInfo, error) {
+	fsys.mu.RLock()
+	defer fsys.mu.RUnlock()
+	n, err := fsys.lookup("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return n.info(), nil
+}
+
+// ReadDir reads the named directory and returns a list of directory entries sorted by filename.
+func (fsys *MemFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	fsys.mu.RLock()
+	defer fsys.mu.RUnlock()
+	n, err := fsys.lookup("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	if n.children == nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	return n.entries(), nil
+}
+
+// ReadFile reads the named file and returns its contents.
+func (fsys *MemFS) ReadFile(name string) ([]byte, error) {
+	fsys.mu.RLock()
+	defer fsys.mu.RUnlock()
+	n, err := fsys.lookup("readfile", name)
+	if err != nil {
+		return nil, err
+	}
+	if n.buf == nil {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: errors.New("is a directory")}
+	}
+	return append([]byte{}, n.buf.Bytes()...), nil
+}
+
+// OpenFile opens the named file with the flag (os.O_RDONLY, os.O_CREATE etc.) and perm.
+func (fsys *MemFS) OpenFile(name string, flag int, perm fs.FileMode) (WriterFile, error) {
+	fsys.mu.Lock()
+	defer fsys.mu.Unlock()
+	n, err := fsys.lookup("open", name)
+	if err != nil {
+		if flag&os.O_CREATE == 0 {
+			return nil, err
+		}
+		parent, elem, err := fsys.lookupParent("open", name)
+		if err != nil {
+			return nil, err
+		}
+		n = &memNode{
+			name:    elem,
+			mode:    perm.Perm(),
+			modTime: fsys.now(),
+			buf:     NewWriteSeekBuffer(0),
+		}
+		parent.children[elem] = n
+	} else if flag&(os.O_CREATE|os.O_EXCL) == os.O_CREATE|os.O_EXCL {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrExist}
+	}
+	writable := flag&(os.O_WRONLY|os.O_RDWR) != 0
+	if n.buf == nil && writable {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: errors.New("is a directory")}
+	}
+	if flag&os.O_TRUNC != 0 && writable {
+		n.buf.Truncate(0)
+		n.modTime = fsys.now()
+	}
+	return &memFile{fsys: fsys, node: n, name: name, flag: flag}, nil
+}
+
+// Mkdir creates a new directory with the specified name and permission bits.
+func (fsys *MemFS) Mkdir(name string, perm fs.FileMode) error {
+	fsys.mu.Lock()
+	defer fsys.mu.Unlock()
+	parent, elem, err := fsys.lookupParent("mkdir", name)
+	if err != nil {
+		return err
+	}
+	if _, ok := parent.children[elem]; ok {
+		return &fs.PathError{Op: "mkdir", Path: name, Err: fs.ErrExist}
+	}
+	parent.children[elem] = &memNode{
+		name:     elem,
+		mode:     fs.ModeDir | perm.Perm(),
+		modTime:  fsys.now(),
+		children: map[string]*memNode{},
+	}
+	return nil
+}
+
+// MkdirAll creates a directory named path, along with any necessary parents.
+func (fsys *MemFS) MkdirAll(name string, perm fs.FileMode) error {
+	if !fs.ValidPath(name) {
+		return &fs.PathError{Op: "mkdir", Path: name, Err: fs.ErrInvalid}
+	}
+	fsys.mu.Lock()
+	defer fsys.mu.Unlock()
+	if name == "." {
+		return nil
+	}
+	n := fsys.root
+	for _, elem := range strings.Split(name, "/") {
+		c, ok := n.children[elem]
+		if !ok {
+			c = &memNode{
+				name:     elem,
+				mode:     fs.ModeDir | perm.Perm(),
+				modTime:  fsys.now(),
+				children: map[string]*memNode{},
+			}
+			n.children[elem] = c
+		} else if c.children == nil {
+			return &fs.PathError{Op: "mkdir", Path: name, Err: errors.New("not a directory")}
+		}
+		n = c
+	}
+	return nil
+}
+
+// Remove removes the named file or empty directory.
+func (fsys *MemFS) Remove(name string) error {
+	fsys.mu.Lock()
+	defer fsys.mu.Unlock()
+	parent, elem, err := fsys.lookupParent("remove", name)
+	if err != nil {
+		return err
+	}
+	n, ok := parent.children[elem]
+	if !ok {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	if len(n.children) > 0 {
+		return &fs.PathError{Op: "remove", Path: name, Err: errors.New("directory not empty")}
+	}
+	delete(parent.children, elem)
+	return nil
+}
+
+// RemoveAll removes name and any children it contains.
+// It returns nil if the name does not exist.
+func (fsys *MemFS) RemoveAll(name string) error {
+	fsys.mu.Lock()
+	defer fsys.mu.Unlock()
+	if name == "." {
+		fsys.root.children = map[string]*memNode{}
+		return nil
+	}
+	parent, elem, err := fsys.lookupParent("remove", name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	delete(parent.children, elem)
+	return nil
+}
+
+// Rename renames (moves) oldname to newname. If newname already exists and
+// is not a directory, Rename replaces it.
+func (fsys *MemFS) Rename(oldname, newname string) error {
+	fsys.mu.Lock()
+	defer fsys.mu.Unlock()
+	linkErr := func(err error) error {
+		return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: err}
+	}
+	oldParent, oldElem, err := fsys.lookupParent("rename", oldname)
+	if err != nil {
+		return linkErr(err.(*fs.PathError).Err)
+	}
+	n, ok := oldParent.children[oldElem]
+	if !ok {
+		return linkErr(fs.ErrNotExist)
+	}
+	newParent, newElem, err := fsys.lookupParent("rename", newname)
+	if err != nil {
+		return linkErr(err.(*fs.PathError).Err)
+	}
+	if n.children != nil && (newname == oldname || strings.HasPrefix(newname, oldname+"/")) {
+		return linkErr(fs.ErrInvalid)
+	}
+	if old, ok := newParent.children[newElem]; ok && old != n {
+		if old.children != nil {
+			return linkErr(fs.ErrExist)
+		}
+		if n.children != nil {
+			return linkErr(errors.New("not a directory"))
+		}
+	}
+	delete(oldParent.children, oldElem)
+	n.name = newElem
+	newParent.children[newElem] = n
+	return nil
+}
+
+// Chmod changes the mode of the named file to mode.
+func (fsys *MemFS) Chmod(name string, mode fs.FileMode) error {
+	fsys.mu.Lock()
+	defer fsys.mu.Unlock()
+	n, err := fsys.lookup("chmod", name)
+	if err != nil {
+		return err
+	}
+	n.mode = n.mode&fs.ModeType | mode.Perm()
+	return nil
+}
+
+// Chtimes changes the modification times of the named file. MemFS does not
+// store the access time.
+func (fsys *MemFS) Chtimes(name string, atime, mtime time.Time) error {
+	fsys.mu.Lock()
+	defer fsys.mu.Unlock()
+	n, err := fsys.lookup("chtimes", name)
+	if err != nil {
+		return err
+	}
+	n.modTime = mtime
+	return nil
+}
+
+// memFile is an opened file of MemFS. Each memFile has its own offset.
+type memFile struct {
+	fsys *MemFS
+	node *memNode
+	name string
+	flag int
+
+	mu      sync.Mutex // guards off, entries and closed; taken before fsys.mu
+	off     int64
+	entries []fs.DirEntry
+	closed  bool
+}
+
+var (
+	_ WriterFile     = (*memFile)(nil)
+	_ fs.ReadDirFile = (*memFile)(nil)
+	_ io.Seeker      = (*memFile)(nil)
+	_ io.ReaderAt    = (*memFile)(nil)
+	_ io.WriterAt    = (*memFile)(nil)
+)
+
+func (f *memFile) check(op string, write bool) error {
+	if f.closed {
+		return &fs.PathError{Op: op, Path: f.name, Err: fs.ErrClosed}
+	}
+	if f.node.buf == nil {
+		return &fs.PathError{Op: op, Path: f.name, Err: errors.New("is a directory")}
+	}
+	if write && f.flag&(os.O_WRONLY|os.O_RDWR) == 0 {
+		return &fs.PathError{Op: op, Path: f.name, Err: fs.ErrPermission}
+	}
+	if !write && f.flag&os.O_WRONLY != 0 {
+		return &fs.PathError{Op: op, Path: f.name, Err: fs.ErrPermission}
+	}
+	return nil
+}
+
+// Stat returns a FileInfo describing the file.
+func (f *memFile) Stat() (fs.FileInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return nil, &fs.PathError{Op: "stat", Path: f.name, Err: fs.ErrClosed}
+	}
+	f.fsys.mu.RLock()
+	defer f.fsys.mu.RUnlock()
+	return f.node.info(), nil
+}
+
+func (f *memFile) readAt(p []byte, off int64) (int, error) {
+	data := f.node.buf.Bytes()
+	if off >= int64(len(data)) {
+		return 0, io.EOF
+	}
+	n := copy(p, data[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Read reads up to len(p) bytes from the offset.
+func (f *memFile) Read(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.check("read", false); err != nil {
+		return 0, err
+	}
+	f.fsys.mu.RLock()
+	defer f.fsys.mu.RUnlock()
+	n, err := f.readAt(p, f.off)
+	f.off += int64(n)
+	if n > 0 && err == io.EOF {
+		err = nil
+	}
+	return n, err
+}
+
+// ReadAt reads len(p) bytes from the offset off.
+func (f *memFile) ReadAt(p []byte, off int64) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.check("read", false); err != nil {
+		return 0, err
+	}
+	if off < 0 {
+		return 0, &fs.PathError{Op: "readat", Path: f.name, Err: errors.New("negative offset")}
+	}
+	f.fsys.mu.RLock()
+	defer f.fsys.mu.RUnlock()
+	return f.readAt(p, off)
+}
+
+func (f *memFile) writeAt(p []byte, off int64) int {
+	f.node.buf.Seek(off, io.SeekStart)
+	n, _ := f.node.buf.Write(p)
+	f.node.modTime = f.fsys.now()
+	return n
+}
+
+// Write writes len(p) bytes to the offset. If the file is opened with
+// os.O_APPEND, Write writes to the end of the file.
+func (f *memFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.check("write", true); err != nil {
+		return 0, err
+	}
+	f.fsys.mu.Lock()
+	defer f.fsys.mu.Unlock()
+	if f.flag&os.O_APPEND != 0 {
+		f.off = int64(f.node.buf.Len())
+	}
+	n := f.writeAt(p, f.off)
+	f.off += int64(n)
+	return n, nil
+}
+
+// WriteAt writes len(p) bytes to the offset off.
+func (f *memFile) WriteAt(p []byte, off int64) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.check("write", true); err != nil {
+		return 0, err
+	}
+	if f.flag&os.O_APPEND != 0 {
+		return 0, &fs.PathError{Op: "writeat", Path: f.name, Err: errors.New("invalid use of WriteAt on file opened with O_APPEND")}
+	}
+	if off < 0 {
+		return 0, &fs.PathError{Op: "writeat", Path: f.name, Err: errors.New("negative offset")}
+	}
+	f.fsys.mu.Lock()
+	defer f.fsys.mu.Unlock()
+	return f.writeAt(p, off), nil
+}
+
+// Seek sets the offset for the next Read or Write.
+func (f *memFile) Seek(offset int64, whence int) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: fs.ErrClosed}
+	}
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.off
+	case io.SeekEnd:
+		f.fsys.mu.RLock()
+		offset += f.node.info().size
+		f.fsys.mu.RUnlock()
+	default:
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: errors.New("invalid whence")}
+	}
+	if offset < 0 {
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: errors.New("negative position")}
+	}
+	f.off = offset
+	return offset, nil
+}
+
+// ReadDir reads the contents of the directory.
+func (f *memFile) ReadDir(count int) ([]fs.DirEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return nil, &fs.PathError{Op: "readdir", Path: f.name, Err: fs.ErrClosed}
+	}
+	if f.node.children == nil {
+		return nil, &fs.PathError{Op: "readdir", Path: f.name, Err: errors.New("not a directory")}
+	}
+	if f.entries == nil {
+		f.fsys.mu.RLock()
+		f.entries = f.node.entries()
+		f.fsys.mu.RUnlock()
+	}
+	rest := f.entries[f.off:]
+	if count <= 0 {
+		f.off += int64(len(rest))
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if count > len(rest) {
+		count = len(rest)
+	}
+	f.off += int64(count)
+	return rest[:count], nil
+}
+
+// Close closes the file.
+func (f *memFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return &fs.PathError{Op: "close", Path: f.name, Err: fs.ErrClosed}
+	}
+	f.closed = true
+	return nil
+}
+
+type memFileInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (i *memFileInfo) Name() string       { return i.name }
+func (i *memFileInfo) Size() int64        { return i.size }
+func (i *memFileInfo) Mode() fs.FileMode  { return i.mode }
+func (i *memFileInfo) ModTime() time.Time { return i.modTime }
+func (i *memFileInfo) IsDir() bool        { return i.mode.IsDir() }
+func (i *memFileInfo) Sys() interface{}   { return nil }
+
+type memDirEntry struct {
+	info *memFileInfo
+}
+
+func (e memDirEntry) Name() string               { return e.info.name }
+func (e memDirEntry) IsDir() bool                { return e.info.IsDir() }
+func (e memDirEntry) Type() fs.FileMode          { return e.info.mode.Type() }
+func (e memDirEntry) Info() (fs.FileInfo, error) { return e.info, nil }
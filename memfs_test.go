@@ -0,0 +1,202 @@
+package io2
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/jarxorg/io2/io2test"
+)
+
+func TestMemFS_Contract(t *testing.T) {
+	content := []byte("Hello, World!")
+	fsys := NewMemFS()
+	if err := WriteFile(fsys, "content.txt", content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	open := func(t *testing.T) fs.File {
+		f, err := fsys.Open("content.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+	create := func(t *testing.T) WriterFile {
+		f, err := Create(fsys, "created.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+	contents := func(t *testing.T) []byte {
+		p, err := fsys.ReadFile("created.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	io2test.TestReader(t, func(t *testing.T) io.Reader { return open(t) }, content)
+	io2test.TestSeeker(t, func(t *testing.T) io.Seeker { return open(t).(io.Seeker) }, content)
+	io2test.TestReaderAt(t, func(t *testing.T) io.ReaderAt { return open(t).(io.ReaderAt) }, content)
+	io2test.TestWriter(t, func(t *testing.T) io.Writer { return create(t) }, func(t *testing.T, _ io.Writer) []byte { return contents(t) })
+	io2test.TestWriterAt(t, func(t *testing.T) io.WriterAt { return create(t).(io.WriterAt) }, func(t *testing.T, _ io.WriterAt) []byte { return contents(t) })
+	io2test.TestCloser(t, func(t *testing.T) io.Closer { return open(t) }, false)
+}
+
+func TestMemFS_FileErrors(t *testing.T) {
+	fsys := NewMemFS()
+	if err := fsys.Mkdir("dir", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(fsys, "a.txt", []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		fn   func() error
+		err  error
+	}{
+		{
+			name: "Write to read only",
+			fn: func() error {
+				f, _ := fsys.Open("a.txt")
+				_, err := f.(io.Writer).Write([]byte("b"))
+				return err
+			},
+			err: fs.ErrPermission,
+		}, {
+			name: "Read from write only",
+			fn: func() error {
+				f, _ := fsys.OpenFile("a.txt", os.O_WRONLY, 0)
+				_, err := f.Read(make([]byte, 1))
+				return err
+			},
+			err: fs.ErrPermission,
+		}, {
+			name: "Read closed",
+			fn: func() error {
+				f, _ := fsys.Open("a.txt")
+				f.Close()
+				_, err := f.Read(make([]byte, 1))
+				return err
+			},
+			err: fs.ErrClosed,
+		}, {
+			name: "Open directory for write",
+			fn: func() error {
+				_, err := fsys.OpenFile("dir", os.O_RDWR, 0)
+				return err
+			},
+		}, {
+			name: "Read directory",
+			fn: func() error {
+				f, _ := fsys.Open("dir")
+				_, err := f.Read(make([]byte, 1))
+				return err
+			},
+		}, {
+			name: "ReadDir file",
+			fn: func() error {
+				f, _ := fsys.Open("a.txt")
+				_, err := f.(fs.ReadDirFile).ReadDir(-1)
+				return err
+			},
+		}, {
+			name: "Rename directory into itself",
+			fn: func() error {
+				return fsys.Rename("dir", "dir/sub")
+			},
+			err: fs.ErrInvalid,
+		},
+	}
+	for _, test := range tests {
+		err := test.fn()
+		if err == nil {
+			t.Errorf("%s: no error", test.name)
+			continue
+		}
+		if test.err != nil && !errors.Is(err, test.err) {
+			t.Errorf("%s: error %v; want %v", test.name, err, test.err)
+		}
+	}
+}
+
+func TestMemFS_Concurrent(t *testing.T) {
+	fsys := NewMemFS()
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("%d.txt", i)
+			if err := WriteFile(fsys, name, []byte(name), 0644); err != nil {
+				t.Error(err)
+				return
+			}
+			if _, err := fs.ReadDir(fsys, "."); err != nil {
+				t.Error(err)
+			}
+			if _, err := fsys.ReadFile(name); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	entries, err := fsys.ReadDir(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 8 {
+		t.Errorf("%d entries; want %d", len(entries), 8)
+	}
+}
+
+func TestMemFS_ConcurrentFile(t *testing.T) {
+	fsys := NewMemFS()
+	data := make([]byte, 1024)
+	if err := WriteFile(fsys, "a.txt", data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := fsys.Open("a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var total int64
+	var mu sync.Mutex
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p := make([]byte, 16)
+			for {
+				n, err := f.Read(p)
+				mu.Lock()
+				total += int64(n)
+				mu.Unlock()
+				if err == io.EOF {
+					return
+				}
+				if err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if total != int64(len(data)) {
+		t.Errorf("read %d bytes; want %d", total, len(data))
+	}
+	if off, err := f.(io.Seeker).Seek(0, io.SeekCurrent); err != nil || off != int64(len(data)) {
+		t.Errorf("offset %d %v; want %d", off, err, len(data))
+	}
+}
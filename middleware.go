@@ -0,0 +1,77 @@
+package io2
+
+// Middleware returns a Delegator that wraps the functions of next.
+// The nil functions of the returned Delegator are left to next, except that
+// replacing ReadFunc clears WriteToFunc, ReadByteFunc and ReadRuneFunc, and
+// replacing WriteFunc clears ReadFromFunc, WriteByteFunc and WriteStringFunc,
+// so that their fallbacks call the wrapped function.
+type Middleware func(next *Delegator) *Delegator
+
+// Use wraps the functions of d with the provided middlewares and returns d.
+// Middlewares are applied in order, so the last one is called first.
+// Each middleware receives a copy of the functions of d, which it can call
+// to continue the call chain.
+func (d *Delegator) Use(mws ...Middleware) *Delegator {
+	for _, mw := range mws {
+		next := *d
+		w := mw(&next)
+		if w == nil {
+			continue
+		}
+		d.override(w)
+	}
+	return d
+}
+
+func (d *Delegator) override(w *Delegator) {
+	// The functions derived from Read or Write would skip the wrapped one, so
+	// they are cleared to fall back to it unless the middleware sets them.
+	if w.ReadFunc != nil {
+		d.ReadFunc = w.ReadFunc
+		d.WriteToFunc = nil
+		d.ReadByteFunc = nil
+		d.ReadRuneFunc = nil
+	}
+	if w.WriteFunc != nil {
+		d.WriteFunc = w.WriteFunc
+		d.ReadFromFunc = nil
+		d.WriteByteFunc = nil
+		d.WriteStringFunc = nil
+	}
+	if w.SeekFunc != nil {
+		d.SeekFunc = w.SeekFunc
+	}
+	if w.CloseFunc != nil {
+		d.CloseFunc = w.CloseFunc
+	}
+	if w.ReadAtFunc != nil {
+		d.ReadAtFunc = w.ReadAtFunc
+	}
+	if w.WriteAtFunc != nil {
+		d.WriteAtFunc = w.WriteAtFunc
+	}
+	if w.ReadFromFunc != nil {
+		d.ReadFromFunc = w.ReadFromFunc
+	}
+	if w.WriteToFunc != nil {
+		d.WriteToFunc = w.WriteToFunc
+	}
+	if w.ReadByteFunc != nil {
+		d.ReadByteFunc = w.ReadByteFunc
+	}
+	if w.UnreadByteFunc != nil {
+		d.UnreadByteFunc = w.UnreadByteFunc
+	}
+	if w.WriteByteFunc != nil {
+		d.WriteByteFunc = w.WriteByteFunc
+	}
+	if w.ReadRuneFunc != nil {
+		d.ReadRuneFunc = w.ReadRuneFunc
+	}
+	if w.UnreadRuneFunc != nil {
+		d.UnreadRuneFunc = w.UnreadRuneFunc
+	}
+	if w.WriteStringFunc != nil {
+		d.WriteStringFunc = w.WriteStringFunc
+	}
+}
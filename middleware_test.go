@@ -0,0 +1,133 @@
+package io2
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDelegator_Use(t *testing.T) {
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next *Delegator) *Delegator {
+			return &Delegator{
+				ReadFunc: func(p []byte) (int, error) {
+					n, err := next.Read(p)
+					calls = append(calls, name+":read:"+string(p[:n]))
+					return n, err
+				},
+				SeekFunc: func(offset int64, whence int) (int64, error) {
+					calls = append(calls, name+":seek")
+					return next.Seek(offset, whence)
+				},
+			}
+		}
+	}
+	upper := func(next *Delegator) *Delegator {
+		return &Delegator{
+			ReadFunc: func(p []byte) (int, error) {
+				n, err := next.Read(p)
+				copy(p, strings.ToUpper(string(p[:n])))
+				return n, err
+			},
+		}
+	}
+	nop := func(next *Delegator) *Delegator {
+		return nil
+	}
+
+	d := DelegateReadSeeker(strings.NewReader("abc"))
+	d.Use(trace("inner"), upper, nop, trace("outer"))
+
+	if _, err := d.Seek(1, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadAll(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "BC" {
+		t.Errorf("got %s; want %s", got, "BC")
+	}
+	want := []string{
+		"outer:seek",
+		"inner:seek",
+		"inner:read:bc",
+		"outer:read:BC",
+		"inner:read:",
+		"outer:read:",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls %v; want %v", calls, want)
+	}
+}
+
+func TestDelegator_UseOverride(t *testing.T) {
+	wantErr := errors.New("test")
+	mw := func(next *Delegator) *Delegator {
+		return &Delegator{
+			ReadFunc:        func(_ []byte) (int, error) { return 0, wantErr },
+			WriteFunc:       func(_ []byte) (int, error) { return 0, wantErr },
+			SeekFunc:        func(_ int64, _ int) (int64, error) { return 0, wantErr },
+			CloseFunc:       func() error { return nil },
+			ReadAtFunc:      func(_ []byte, _ int64) (int, error) { return 0, wantErr },
+			WriteAtFunc:     func(_ []byte, _ int64) (int, error) { return 0, wantErr },
+			ReadFromFunc:    func(_ io.Reader) (int64, error) { return 0, wantErr },
+			WriteToFunc:     func(_ io.Writer) (int64, error) { return 0, wantErr },
+			ReadByteFunc:    func() (byte, error) { return 0, wantErr },
+			UnreadByteFunc:  func() error { return wantErr },
+			WriteByteFunc:   func(_ byte) error { return wantErr },
+			ReadRuneFunc:    func() (rune, int, error) { return 0, 0, wantErr },
+			UnreadRuneFunc:  func() error { return wantErr },
+			WriteStringFunc: func(_ string) (int, error) { return 0, wantErr },
+		}
+	}
+	testDelegatorErrors(t, (&Delegator{}).Use(mw), wantErr)
+}
+
+func TestDelegator_UseDerived(t *testing.T) {
+	var read, written int
+	count := func(next *Delegator) *Delegator {
+		return &Delegator{
+			ReadFunc: func(p []byte) (int, error) {
+				n, err := next.Read(p)
+				read += n
+				return n, err
+			},
+			WriteFunc: func(p []byte) (int, error) {
+				n, err := next.Write(p)
+				written += n
+				return n, err
+			},
+		}
+	}
+
+	r := Delegate(strings.NewReader("hello")).Use(count)
+	if _, err := io.Copy(ioutil.Discard, r); err != nil {
+		t.Fatal(err)
+	}
+	if read != 5 {
+		t.Errorf("read %d; want %d", read, 5)
+	}
+
+	buf := &strings.Builder{}
+	w := Delegate(buf).Use(count)
+	if _, err := w.WriteString("abc"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteByte('d'); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.ReadFrom(strings.NewReader("ef")); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "abcdef" {
+		t.Errorf("got %s; want %s", buf.String(), "abcdef")
+	}
+	if written != 6 {
+		t.Errorf("written %d; want %d", written, 6)
+	}
+}
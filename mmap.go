@@ -0,0 +1,174 @@
+//go:build linux
+// +build linux
+
+package io2
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// MmapWriteSeekBuffer is a WriteSeekBuffer whose storage is a memory-mapped file.
+// Bytes returns a view of the mapping without copying; the view is valid until
+// the next write that grows the file past the mapping, or Close.
+type MmapWriteSeekBuffer struct {
+	*WriteSeekBuffer
+	m *mmapStore
+}
+
+// NewMmapWriteSeekBuffer maps f, which must be opened for reading and writing,
+// and returns an MmapWriteSeekBuffer with the contents of f and the offset 0.
+// The buffer owns f; Close closes it.
+func NewMmapWriteSeekBuffer(f *os.File) (*MmapWriteSeekBuffer, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := int(info.Size())
+	m := &mmapStore{f: f}
+	if err := m.remap(size); err != nil {
+		return nil, err
+	}
+	m.size = size
+	b := &WriteSeekBuffer{
+		s:    m,
+		len:  size,
+		data: addExtent(nil, Extent{Len: size}),
+	}
+	return &MmapWriteSeekBuffer{WriteSeekBuffer: b, m: m}, nil
+}
+
+// Sync flushes the mapping to the file with msync(2) and truncates the file to
+// the length of the buffer.
+func (b *MmapWriteSeekBuffer) Sync() error {
+	return b.m.sync(b.len)
+}
+
+// Close calls Sync, unmaps the file and closes it. Unlike WriteSeekBuffer,
+// Close keeps the contents. The buffer is empty after Close, and writes to it
+// return os.ErrClosed. Close is idempotent.
+func (b *MmapWriteSeekBuffer) Close() error {
+	if b.m.closed {
+		return nil
+	}
+	err := b.Sync()
+	if uerr := b.m.remap(0); err == nil {
+		err = uerr
+	}
+	if cerr := b.m.f.Close(); err == nil {
+		err = cerr
+	}
+	b.m.closed = true
+	b.m.size = 0
+	*b.WriteSeekBuffer = WriteSeekBuffer{s: b.m}
+	return err
+}
+
+// mmapStore is a store on a memory-mapped file. The file grows in chunks, so
+// it can be larger than the length of the buffer until sync.
+type mmapStore struct {
+	f    *os.File
+	data []byte
+	// size is the size of the file. It is at most len(data).
+	size   int
+	closed bool
+}
+
+// remap unmaps the current mapping and maps n bytes of the file.
+func (s *mmapStore) remap(n int) error {
+	if s.data != nil {
+		if err := syscall.Munmap(s.data); err != nil {
+			return err
+		}
+		s.data = nil
+	}
+	if n == 0 {
+		return nil
+	}
+	data, err := syscall.Mmap(int(s.f.Fd()), 0, n, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return err
+	}
+	s.data = data
+	return nil
+}
+
+func (s *mmapStore) grow(n int) error {
+	if n <= s.size {
+		return nil
+	}
+	size := 2 * s.size
+	if page := os.Getpagesize(); size < page {
+		size = page
+	}
+	if size < n {
+		size = n
+	}
+	if err := s.f.Truncate(int64(size)); err != nil {
+		return err
+	}
+	s.size = size
+	if size > len(s.data) {
+		return s.remap(size)
+	}
+	return nil
+}
+
+func (s *mmapStore) readAt(p []byte, off int) error {
+	copy(p, s.data[off:])
+	return nil
+}
+
+func (s *mmapStore) writeAt(p []byte, off int) error {
+	if s.closed {
+		return os.ErrClosed
+	}
+	if err := s.grow(off + len(p)); err != nil {
+		return err
+	}
+	copy(s.data[off:], p)
+	return nil
+}
+
+// truncate shrinks the file and keeps the mapping. The truncated bytes read
+// back as zeros when the file grows again.
+func (s *mmapStore) truncate(n int) error {
+	if s.closed {
+		return nil
+	}
+	if n >= s.size {
+		return s.grow(n)
+	}
+	if err := s.f.Truncate(int64(n)); err != nil {
+		return err
+	}
+	s.size = n
+	return nil
+}
+
+func (s *mmapStore) bytes(n int) []byte {
+	return s.data[:n:n]
+}
+
+func (s *mmapStore) snapshot(n int) store {
+	return &bytesStore{buf: append([]byte{}, s.data[:n]...)}
+}
+
+func (s *mmapStore) sync(n int) error {
+	if s.size > 0 {
+		_, _, errno := syscall.Syscall(syscall.SYS_MSYNC,
+			uintptr(unsafe.Pointer(&s.data[0])), uintptr(s.size), syscall.MS_SYNC)
+		if errno != 0 {
+			return errno
+		}
+	}
+	if s.size == n {
+		return nil
+	}
+	if err := s.f.Truncate(int64(n)); err != nil {
+		return err
+	}
+	s.size = n
+	return nil
+}
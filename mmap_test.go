@@ -0,0 +1,173 @@
+//go:build linux
+// +build linux
+
+package io2
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jarxorg/io2/io2test"
+)
+
+func newTestMmapWriteSeekBuffer(t *testing.T, content string) (*MmapWriteSeekBuffer, string) {
+	t.Helper()
+	name := filepath.Join(t.TempDir(), "mmap")
+	if err := ioutil.WriteFile(name, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(name, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewMmapWriteSeekBuffer(f)
+	if err != nil {
+		f.Close()
+		t.Fatal(err)
+	}
+	return b, name
+}
+
+func TestMmapWriteSeekBuffer(t *testing.T) {
+	b, name := newTestMmapWriteSeekBuffer(t, "123456789")
+
+	p := make([]byte, 3)
+	if _, err := io.ReadFull(b, p); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(p), "123"; got != want {
+		t.Errorf("read %s; want %s", got, want)
+	}
+
+	b.Write([]byte(`abc`))
+	b.Seek(5000, io.SeekStart)
+	b.Write([]byte(`end`))
+	if got := len(b.Bytes()); got != 5003 {
+		t.Errorf("bytes len %d; want %d", got, 5003)
+	}
+
+	b.Truncate(8)
+	b.Seek(10, io.SeekStart)
+	b.Write([]byte(`x`))
+	if got, want := string(b.Bytes()), "123abc78\x00\x00x"; got != want {
+		t.Errorf("bytes %q; want %q", got, want)
+	}
+
+	if err := b.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "123abc78\x00\x00x"; string(got) != want {
+		t.Errorf("file %q; want %q", got, want)
+	}
+
+	b.Write([]byte(`yz`))
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+	got, err = ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "123abc78\x00\x00xyz"; string(got) != want {
+		t.Errorf("file after close %q; want %q", got, want)
+	}
+}
+
+func TestMmapWriteSeekBuffer_TruncateExtend(t *testing.T) {
+	b, name := newTestMmapWriteSeekBuffer(t, "123")
+	defer b.Close()
+
+	b.Truncate(1)
+	b.Truncate(5000)
+	if got := b.Bytes(); len(got) != 5000 || string(got[:3]) != "1\x00\x00" {
+		t.Errorf("bytes len %d prefix %q; want %d %q", len(got), got[:3], 5000, "1\x00\x00")
+	}
+	if err := b.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != 5000 {
+		t.Errorf("size %d; want %d", info.Size(), 5000)
+	}
+}
+
+func TestMmapWriteSeekBuffer_Empty(t *testing.T) {
+	b, name := newTestMmapWriteSeekBuffer(t, "")
+
+	if b.Len() != 0 || len(b.Bytes()) != 0 {
+		t.Errorf("len %d; want %d", b.Len(), 0)
+	}
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != 0 {
+		t.Errorf("size %d; want %d", info.Size(), 0)
+	}
+}
+
+func TestMmapWriteSeekBuffer_Contract(t *testing.T) {
+	content := []byte("Hello, World!")
+	var bufs []*MmapWriteSeekBuffer
+	defer func() {
+		for _, b := range bufs {
+			b.Close()
+		}
+	}()
+	newBuffer := func(t *testing.T, content string) *MmapWriteSeekBuffer {
+		b, _ := newTestMmapWriteSeekBuffer(t, content)
+		bufs = append(bufs, b)
+		return b
+	}
+
+	io2test.TestWriter(t, func(t *testing.T) io.Writer {
+		return newBuffer(t, "")
+	}, func(t *testing.T, w io.Writer) []byte {
+		return w.(*MmapWriteSeekBuffer).Bytes()
+	})
+	io2test.TestWriterAt(t, func(t *testing.T) io.WriterAt {
+		return newBuffer(t, "")
+	}, func(t *testing.T, w io.WriterAt) []byte {
+		return w.(*MmapWriteSeekBuffer).Bytes()
+	})
+	io2test.TestClampingSeeker(t, func(t *testing.T) io.Seeker { return newBuffer(t, string(content)) }, content)
+	io2test.TestReader(t, func(t *testing.T) io.Reader { return newBuffer(t, string(content)) }, content)
+	io2test.TestReaderAt(t, func(t *testing.T) io.ReaderAt { return newBuffer(t, string(content)) }, content)
+	io2test.TestCloser(t, func(t *testing.T) io.Closer { return newBuffer(t, string(content)) }, true)
+}
+
+func TestMmapWriteSeekBuffer_Closed(t *testing.T) {
+	b, name := newTestMmapWriteSeekBuffer(t, "123")
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Close(); err != nil {
+		t.Errorf("close twice: %v", err)
+	}
+	if _, err := b.Write([]byte(`x`)); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("write error %v; want %v", err, os.ErrClosed)
+	}
+	if err := b.Sync(); err != nil {
+		t.Errorf("sync after close: %v", err)
+	}
+	if b.Len() != 0 || len(b.Bytes()) != 0 {
+		t.Errorf("len %d after close; want %d", b.Len(), 0)
+	}
+	if got, _ := ioutil.ReadFile(name); string(got) != "123" {
+		t.Errorf("file %q; want %q", got, "123")
+	}
+}
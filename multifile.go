@@ -0,0 +1,133 @@
+package io2
+
+import (
+	"container/list"
+	"io"
+	"os"
+)
+
+// NewLazyMultiFileReader returns a ReadSeekCloser that's the logical
+// concatenation of the files. The files are stated at construction but opened
+// only when Read enters them and closed when Read reaches their end. At most
+// maxOpen files are open at the same time; the least recently used file is
+// closed to open another one, and reopened at the same offset when it is read
+// again. If maxOpen is not positive, the number of open files is unlimited.
+func NewLazyMultiFileReader(maxOpen int, filenames ...string) (MultiReadSeekCloser, error) {
+	lru := &fileLRU{max: maxOpen, l: list.New()}
+	ds := make([]*singleReader, len(filenames))
+	for i, filename := range filenames {
+		info, err := osStat(filename)
+		if err != nil {
+			return nil, err
+		}
+		ds[i] = &singleReader{
+			ReadSeekCloser: &lazyFile{name: filename, lru: lru},
+			length:         info.Size(),
+		}
+	}
+	return newMultiReader(ds), nil
+}
+
+// fileLRU bounds the number of open lazyFiles.
+type fileLRU struct {
+	max int
+	l   *list.List
+}
+
+// touch marks f as the most recently used, closing the least recently used
+// files over the maximum.
+func (lru *fileLRU) touch(f *lazyFile) {
+	if f.elem != nil {
+		lru.l.MoveToFront(f.elem)
+		return
+	}
+	f.elem = lru.l.PushFront(f)
+	for lru.max > 0 && lru.l.Len() > lru.max {
+		lru.l.Back().Value.(*lazyFile).release()
+	}
+}
+
+func (lru *fileLRU) remove(f *lazyFile) {
+	if f.elem != nil {
+		lru.l.Remove(f.elem)
+		f.elem = nil
+	}
+}
+
+// lazyFile is a file that is opened on the first Read and closed at io.EOF or
+// by fileLRU. Seek only records the offset while the file is closed.
+type lazyFile struct {
+	name string
+	lru  *fileLRU
+	elem *list.Element
+	f    *os.File
+	off  int64
+}
+
+var _ io.ReadSeekCloser = (*lazyFile)(nil)
+
+func (f *lazyFile) open() error {
+	if f.f != nil {
+		f.lru.touch(f)
+		return nil
+	}
+	file, err := osOpen(f.name)
+	if err != nil {
+		return err
+	}
+	if f.off != 0 {
+		if _, err := file.Seek(f.off, io.SeekStart); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	f.f = file
+	f.lru.touch(f)
+	return nil
+}
+
+// release closes the file and keeps the offset to reopen it.
+func (f *lazyFile) release() error {
+	f.lru.remove(f)
+	if f.f == nil {
+		return nil
+	}
+	err := f.f.Close()
+	f.f = nil
+	return err
+}
+
+func (f *lazyFile) Read(p []byte) (int, error) {
+	if err := f.open(); err != nil {
+		return 0, err
+	}
+	n, err := f.f.Read(p)
+	f.off += int64(n)
+	if err == io.EOF {
+		// A file read to the end is not read again unless seeked back.
+		if cerr := f.release(); cerr != nil {
+			return n, cerr
+		}
+	}
+	return n, err
+}
+
+func (f *lazyFile) Seek(offset int64, whence int) (int64, error) {
+	if f.f == nil && whence == io.SeekStart && offset >= 0 {
+		f.off = offset
+		return offset, nil
+	}
+	if err := f.open(); err != nil {
+		return 0, err
+	}
+	n, err := f.f.Seek(offset, whence)
+	if err != nil {
+		return 0, err
+	}
+	f.off = n
+	return n, nil
+}
+
+func (f *lazyFile) Close() error {
+	return f.release()
+}
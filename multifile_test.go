@@ -0,0 +1,194 @@
+package io2
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/jarxorg/io2/io2test"
+)
+
+func TestNewLazyMultiFileReader(t *testing.T) {
+	filenames, done, err := testMultiFilenames("abc", "", "de", "fgh", "i")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer done()
+
+	opens := 0
+	osOpenOrg := osOpen
+	defer func() { osOpen = osOpenOrg }()
+	osOpen = func(filename string) (*os.File, error) {
+		opens++
+		return osOpenOrg(filename)
+	}
+
+	r, err := NewLazyMultiFileReader(2, filenames...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if opens != 0 {
+		t.Errorf("opens at construction %d; want %d", opens, 0)
+	}
+
+	lru := r.(*multiReader).rs[0].ReadSeekCloser.(*lazyFile).lru
+	p := make([]byte, 2)
+	var got []byte
+	for {
+		n, err := r.Read(p)
+		got = append(got, p[:n]...)
+		if lru.l.Len() > 2 {
+			t.Fatalf("open files %d; want <= %d", lru.l.Len(), 2)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want := "abcdefghi"; string(got) != want {
+		t.Errorf("read %s; want %s", got, want)
+	}
+
+	// The first file has been closed by the LRU and is reopened at the offset.
+	if _, err := r.Seek(1, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	got, err = ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "bcdefghi"; string(got) != want {
+		t.Errorf("read %s; want %s", got, want)
+	}
+
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if lru.l.Len() != 0 {
+		t.Errorf("open files after close %d; want %d", lru.l.Len(), 0)
+	}
+}
+
+func TestNewLazyMultiFileReader_ReleaseAtEOF(t *testing.T) {
+	filenames, done, err := testMultiFilenames("abc", "de", "fgh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer done()
+
+	r, err := NewLazyMultiFileReader(0, filenames...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	lru := r.(*multiReader).rs[0].ReadSeekCloser.(*lazyFile).lru
+	p := make([]byte, 2)
+	for {
+		_, err := r.Read(p)
+		if lru.l.Len() > 1 {
+			t.Fatalf("open files %d; want <= %d", lru.l.Len(), 1)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if lru.l.Len() != 0 {
+		t.Errorf("open files at EOF %d; want %d", lru.l.Len(), 0)
+	}
+
+	if _, err := r.Seek(4, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "efgh"; string(got) != want {
+		t.Errorf("read %s; want %s", got, want)
+	}
+	if lru.l.Len() != 0 {
+		t.Errorf("open files at EOF %d; want %d", lru.l.Len(), 0)
+	}
+}
+
+func TestNewLazyMultiFileReader_Errors(t *testing.T) {
+	if _, err := NewLazyMultiFileReader(0, "LICENSE", "notfound"); !os.IsNotExist(err) {
+		t.Errorf("error %v; want not exist", err)
+	}
+
+	osOpenOrg := osOpen
+	defer func() { osOpen = osOpenOrg }()
+	osOpen = func(filename string) (*os.File, error) {
+		return nil, errors.New("test-error")
+	}
+	r, err := NewLazyMultiFileReader(0, "LICENSE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if _, err := r.Read(make([]byte, 1)); err == nil || err.Error() != "test-error" {
+		t.Errorf("read error %v; want %s", err, "test-error")
+	}
+}
+
+func TestNewMultiFileReader_CloseOnError(t *testing.T) {
+	var opened []*os.File
+	osOpenOrg := osOpen
+	defer func() { osOpen = osOpenOrg }()
+	osOpen = func(filename string) (*os.File, error) {
+		if len(opened) == 2 {
+			return nil, errors.New("test-error")
+		}
+		f, err := osOpenOrg(filename)
+		if err == nil {
+			opened = append(opened, f)
+		}
+		return f, err
+	}
+
+	if _, err := NewMultiFileReader("LICENSE", "LICENSE", "LICENSE"); err == nil {
+		t.Fatal("no error")
+	}
+	for i, f := range opened {
+		if err := f.Close(); !errors.Is(err, os.ErrClosed) {
+			t.Errorf("files[%d] is not closed: %v", i, err)
+		}
+	}
+}
+
+func TestNewLazyMultiFileReader_Contract(t *testing.T) {
+	contents := []string{"abc", "", "de", "f"}
+	content := []byte("abcdef")
+	filenames, done, err := testMultiFilenames(contents...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer done()
+
+	var rs []MultiReadSeekCloser
+	defer func() {
+		for _, r := range rs {
+			r.Close()
+		}
+	}()
+	newReader := func(t *testing.T) MultiReadSeekCloser {
+		r, err := NewLazyMultiFileReader(1, filenames...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rs = append(rs, r)
+		return r
+	}
+	io2test.TestReader(t, func(t *testing.T) io.Reader { return newReader(t) }, content)
+	io2test.TestSeeker(t, func(t *testing.T) io.Seeker { return newReader(t) }, content)
+	io2test.TestCloser(t, func(t *testing.T) io.Closer { return newReader(t) }, true)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 )
 
@@ -34,12 +35,12 @@ type MultiReadSeekCloser interface {
 	io.Closer
 }
 
-var errSkip = errors.New("skip")
-
 type singleReader struct {
 	io.ReadSeekCloser
 	off    int64
 	length int64
+	// start is the offset of the reader in the concatenation.
+	start int64
 }
 
 type multiReader struct {
@@ -50,6 +51,16 @@ type multiReader struct {
 
 var _ io.ReadSeekCloser = (*multiReader)(nil)
 
+// newMultiReader returns a multiReader that indexes the offsets of the readers.
+func newMultiReader(ds []*singleReader) *multiReader {
+	length := int64(0)
+	for _, d := range ds {
+		d.start = length
+		length += d.length
+	}
+	return &multiReader{rs: ds, length: length}
+}
+
 // NewMultiReader creates a Reader that's the logical concatenation
 // of the provided input readers.
 func NewMultiReader(rs ...io.Reader) MultiReader {
@@ -83,7 +94,6 @@ func NewMultiReadSeeker(rs ...io.ReadSeeker) (MultiReadSeeker, error) {
 // NewMultiReadSeekCloser creates a ReadSeekCloser that's the logical
 // concatenation of the provided input readers.
 func NewMultiReadSeekCloser(rs ...io.ReadSeekCloser) (MultiReadSeekCloser, error) {
-	length := int64(0)
 	ds := make([]*singleReader, len(rs))
 	for i, r := range rs {
 		n, err := r.Seek(0, io.SeekEnd)
@@ -97,43 +107,51 @@ func NewMultiReadSeekCloser(rs ...io.ReadSeekCloser) (MultiReadSeekCloser, error
 			ReadSeekCloser: Delegate(r),
 			length:         n,
 		}
-		length += n
 	}
-	return &multiReader{rs: ds, length: length}, nil
+	return newMultiReader(ds), nil
 }
 
 func NewMultiStringReader(strs ...string) MultiReadSeeker {
-	length := int64(0)
 	ds := make([]*singleReader, len(strs))
 	for i, str := range strs {
 		ds[i] = &singleReader{
 			ReadSeekCloser: NopReadSeekCloser(strings.NewReader(str)),
 			length:         int64(len(str)),
 		}
-		length += ds[i].length
 	}
-	return &multiReader{rs: ds, length: length}
+	return newMultiReader(ds)
 }
 
+// NewMultiFileReader opens the files and returns a ReadSeekCloser that's the
+// logical concatenation of them. If an error occurs, the files already opened
+// are closed. See NewLazyMultiFileReader to open the files on demand.
 func NewMultiFileReader(filenames ...string) (MultiReadSeekCloser, error) {
-	length := int64(0)
 	ds := make([]*singleReader, len(filenames))
+	closeAll := func() {
+		for _, d := range ds {
+			if d != nil {
+				d.Close()
+			}
+		}
+	}
 	for i, filename := range filenames {
 		f, err := osOpen(filename)
 		if err != nil {
+			closeAll()
 			return nil, err
 		}
 		info, err := fsStat(f)
 		if err != nil {
+			f.Close()
+			closeAll()
 			return nil, err
 		}
 		ds[i] = &singleReader{
 			ReadSeekCloser: f,
 			length:         info.Size(),
 		}
-		length += ds[i].length
 	}
-	return &multiReader{rs: ds, length: length}, nil
+	return newMultiReader(ds), nil
 }
 
 // Current returns a current index of multiple readers.
@@ -141,194 +159,111 @@ func (mr *multiReader) Current() int {
 	return mr.current
 }
 
-func (mr *multiReader) each(i int, offset int64, fn func(r *singleReader) error) error {
-	mr.current = i
-	if offset >= 0 {
-		for ; mr.current < len(mr.rs); mr.current++ {
-			if err := fn(mr.rs[mr.current]); err != nil {
-				if err == errSkip {
-					err = nil
+func (mr *multiReader) Read(p []byte) (int, error) {
+	if len(mr.rs) == 0 {
+		return 0, io.EOF
+	}
+	off := 0
+	for {
+		r := mr.rs[mr.current]
+		n, err := r.Read(p[off:])
+		r.off += int64(n)
+		off += n
+		if err == io.EOF {
+			if mr.current == len(mr.rs)-1 {
+				if off > 0 {
+					return off, nil
 				}
-				return err
+				return 0, io.EOF
 			}
+			mr.current++
+			if err := mr.rewind(mr.rs[mr.current]); err != nil {
+				return off, err
+			}
+			continue
 		}
-		if mr.current >= len(mr.rs) {
-			mr.current = len(mr.rs) - 1
+		if err != nil {
+			return off, err
 		}
-		return nil
-	}
-	for ; mr.current >= 0; mr.current-- {
-		if err := fn(mr.rs[mr.current]); err != nil {
-			if err == errSkip {
-				err = nil
-			}
-			return err
+		if n == 0 || off >= len(p) {
+			return off, nil
 		}
 	}
-	if mr.current < 0 {
-		mr.current = 0
+}
+
+// rewind seeks the reader that a previous Read or Seek left at another offset
+// to the start. The readers are rewound only when Read enters them.
+func (mr *multiReader) rewind(r *singleReader) error {
+	if r.off == 0 {
+		return nil
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return err
 	}
+	r.off = 0
 	return nil
 }
 
-func (mr *multiReader) Read(p []byte) (n int, err error) {
-	if mr.current >= len(mr.rs) {
-		return 0, io.EOF
+func (mr *multiReader) offset() int64 {
+	if len(mr.rs) == 0 {
+		return 0
 	}
-	off := 0
-	for ; mr.current < len(mr.rs); mr.current++ {
-		r := mr.rs[mr.current]
-		n, err = r.Read(p[off:])
-		if err != nil {
-			return 0, err
-		}
-		r.off += int64(n)
-		off += n
-		if off >= len(p) {
-			return off, nil
-		}
-	}
-	return off, nil
+	r := mr.rs[mr.current]
+	return r.start + r.off
 }
 
 func (mr *multiReader) Seek(offset int64, whence int) (int64, error) {
 	switch whence {
 	case io.SeekStart:
-		return mr.seekStart(offset, 0)
 	case io.SeekCurrent:
-		return mr.seekCurrent(offset)
+		offset += mr.offset()
 	case io.SeekEnd:
-		return mr.seekEnd(offset, len(mr.rs)-1)
+		offset += mr.length
+	default:
+		return 0, errors.New("invalid whence")
 	}
-	return 0, errors.New("invalid whence")
+	return mr.seek(offset)
 }
 
 // SeekReader sets the offset of multiple readers. The current starts 0.
 func (mr *multiReader) SeekReader(current int) (int64, error) {
-	var offset int64
-	for i, r := range mr.rs {
-		if i >= current {
-			break
-		}
-		offset += r.length
+	offset := mr.length
+	if current < 0 {
+		offset = 0
+	} else if current < len(mr.rs) {
+		offset = mr.rs[current].start
 	}
 	return mr.Seek(offset, io.SeekStart)
 }
 
-func (mr *multiReader) resetTails() error {
-	for i := mr.current + 1; i < len(mr.rs); i++ {
-		r := mr.rs[i]
-		if _, err := r.Seek(0, io.SeekStart); err != nil {
-			return err
-		}
-		r.off = 0
+// seek sets the offset to the reader that contains the offset. The offset
+// past the end is set to the last reader. Only that reader is seeked; the
+// following readers are rewound when Read enters them.
+func (mr *multiReader) seek(offset int64) (int64, error) {
+	if len(mr.rs) == 0 {
+		return offset, nil
 	}
-	return nil
-}
-
-func (mr *multiReader) seekStart(offset int64, start int) (int64, error) {
-	off := int64(0)
-	for i := 0; i < start; i++ {
-		off += mr.rs[i].length
-	}
-	err := mr.each(start, offset, func(r *singleReader) error {
-		safeOffset := offset
-		if mr.current != len(mr.rs)-1 && safeOffset > (r.length-r.off) {
-			safeOffset = (r.length - r.off)
-		}
-		n, err := r.Seek(safeOffset, io.SeekStart)
-		if err != nil {
-			return err
-		}
-		r.off = n
-		if offset < r.length {
-			off += offset
-			return errSkip
-		}
-		offset -= safeOffset
-		off += safeOffset
-		return nil
+	i := sort.Search(len(mr.rs)-1, func(k int) bool {
+		r := mr.rs[k]
+		return offset < r.start+r.length
 	})
-	if err != nil {
-		return 0, err
-	}
-	if err := mr.resetTails(); err != nil {
-		return 0, err
-	}
-	return off, nil
-}
-
-func (mr *multiReader) seekCurrent(offset int64) (int64, error) {
-	off := int64(0)
-	for i := 0; i < mr.current; i++ {
-		off += mr.rs[i].length
-	}
-
-	r := mr.rs[mr.current]
-	safeOffset := offset
-	if offset >= 0 {
-		if mr.current != len(mr.rs)-1 && safeOffset > (r.length-r.off) {
-			safeOffset = (r.length - r.off)
-		}
-	} else {
-		if mr.current != 0 && -safeOffset > r.off {
-			safeOffset = -r.off
-		}
-	}
-	n, err := r.Seek(safeOffset, io.SeekCurrent)
+	r := mr.rs[i]
+	n, err := r.Seek(offset-r.start, io.SeekStart)
 	if err != nil {
 		return 0, err
 	}
 	r.off = n
-	if diffset := offset - safeOffset; diffset != 0 {
-		if offset >= 0 {
-			return mr.seekStart(diffset, mr.current+1)
-		}
-		return mr.seekEnd(diffset, mr.current-1)
-	}
-	if err := mr.resetTails(); err != nil {
-		return 0, err
-	}
-	return off + n, nil
-}
-
-func (mr *multiReader) seekEnd(offset int64, end int) (int64, error) {
-	off := mr.length
-	for i := end + 1; i < len(mr.rs); i++ {
-		off -= mr.rs[i].length
-	}
-	err := mr.each(end, offset, func(r *singleReader) error {
-		safeOffset := offset
-		if mr.current != 0 && -safeOffset > r.length {
-			safeOffset = -r.length
-		}
-		n, err := r.Seek(safeOffset, io.SeekEnd)
-		if err != nil {
-			return err
-		}
-		r.off = n
-		if -offset < r.length {
-			off += offset
-			return errSkip
-		}
-		offset += r.length
-		off -= r.length
-		return nil
-	})
-	if err != nil {
-		return 0, err
-	}
-	return off, nil
+	mr.current = i
+	return r.start + n, nil
 }
 
 func (mr *multiReader) Close() error {
 	var errs []string
-	mr.each(0, 1, func(r *singleReader) error {
+	for _, r := range mr.rs {
 		if err := r.Close(); err != nil {
 			errs = append(errs, err.Error())
 		}
-		return nil
-	})
+	}
 	if len(errs) > 0 {
 		return fmt.Errorf("failed to close: %s", strings.Join(errs, "; "))
 	}
@@ -10,6 +10,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/jarxorg/io2/io2test"
 )
 
 func testMultiFilenames(contents ...string) ([]string, func(), error) {
@@ -175,23 +177,6 @@ func TestMultiSeek(t *testing.T) {
 			whence: io.SeekStart,
 			n:      0,
 			after:  "abcdefghi",
-		}, {
-			readers: func() []io.ReadSeekCloser {
-				r0 := NopReadSeekCloser(strings.NewReader("abc"))
-				r1 := strings.NewReader("def")
-				d1 := Delegate(r1)
-				r, err := NewMultiReadSeekCloser(r0, d1)
-				if err != nil {
-					t.Fatal(err)
-				}
-				d1.SeekFunc = func(offset int64, whence int) (int64, error) {
-					return 0, errors.New("failed to reset tails")
-				}
-				return []io.ReadSeekCloser{r}
-			},
-			offset: 0,
-			whence: io.SeekStart,
-			errstr: "failed to reset tails",
 		}, {
 			readers: func() []io.ReadSeekCloser {
 				return []io.ReadSeekCloser{
@@ -270,23 +255,6 @@ func TestMultiSeek(t *testing.T) {
 			whence: io.SeekCurrent,
 			n:      0,
 			after:  "abcdefghi",
-		}, {
-			readers: func() []io.ReadSeekCloser {
-				r0 := NopReadSeekCloser(strings.NewReader("abc"))
-				r1 := strings.NewReader("def")
-				d1 := Delegate(r1)
-				r, err := NewMultiReadSeekCloser(r0, d1)
-				if err != nil {
-					t.Fatal(err)
-				}
-				d1.SeekFunc = func(offset int64, whence int) (int64, error) {
-					return 0, errors.New("failed to reset tails")
-				}
-				return []io.ReadSeekCloser{r}
-			},
-			offset: 0,
-			whence: io.SeekCurrent,
-			errstr: "failed to reset tails",
 		}, {
 			readers: func() []io.ReadSeekCloser {
 				return []io.ReadSeekCloser{
@@ -447,6 +415,82 @@ func TestMultiClose(t *testing.T) {
 	}
 }
 
+func TestMultiSeek_Index(t *testing.T) {
+	seeks := make([]int, 100)
+	rs := make([]io.ReadSeekCloser, len(seeks))
+	for i := range rs {
+		i := i
+		d := Delegate(strings.NewReader(fmt.Sprintf("%02d", i)))
+		seek := d.SeekFunc
+		d.SeekFunc = func(offset int64, whence int) (int64, error) {
+			seeks[i]++
+			return seek(offset, whence)
+		}
+		rs[i] = d
+	}
+	r, err := NewMultiReadSeekCloser(rs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range seeks {
+		seeks[i] = 0
+	}
+
+	if _, err := r.Seek(101, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	p := make([]byte, 5)
+	if _, err := io.ReadFull(r, p); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(p), "05152"; got != want {
+		t.Errorf("read %s; want %s", got, want)
+	}
+	if _, err := r.Seek(-1, io.SeekCurrent); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadFull(r, p); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(p), "25354"; got != want {
+		t.Errorf("read %s; want %s", got, want)
+	}
+	for i, n := range seeks {
+		want := 0
+		if i == 50 || i == 52 {
+			want = 1
+		}
+		if n != want {
+			t.Errorf("seeks[%d] %d; want %d", i, n, want)
+		}
+	}
+}
+
+func TestMultiSeek_RewindError(t *testing.T) {
+	r0 := NopReadSeekCloser(strings.NewReader("abc"))
+	d1 := Delegate(strings.NewReader("def"))
+	r, err := NewMultiReadSeekCloser(r0, d1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ioutil.ReadAll(r); err != nil {
+		t.Fatal(err)
+	}
+	d1.SeekFunc = func(offset int64, whence int) (int64, error) {
+		return 0, errors.New("failed to rewind")
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadAll(r)
+	if err == nil || err.Error() != "failed to rewind" {
+		t.Errorf("read error %v; want %s", err, "failed to rewind")
+	}
+	if string(got) != "abc" {
+		t.Errorf("read %s; want %s", got, "abc")
+	}
+}
+
 func TestMultiSeekReader(t *testing.T) {
 	tests := []struct {
 		reader  func() MultiReadSeeker
@@ -535,3 +579,70 @@ func TestNewMultiFileReader_fsStatError(t *testing.T) {
 		t.Fatalf("unexpected error %v", err)
 	}
 }
+
+func TestMultiReader_Contract(t *testing.T) {
+	contents := []string{"abc", "", "de", "f"}
+	content := []byte(strings.Join(contents, ""))
+	filenames, done, err := testMultiFilenames(contents...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer done()
+
+	tests := []struct {
+		name      string
+		newReader func(t *testing.T) MultiReadSeeker
+	}{
+		{
+			name: "NewMultiStringReader",
+			newReader: func(t *testing.T) MultiReadSeeker {
+				return NewMultiStringReader(contents...)
+			},
+		}, {
+			name: "NewMultiReadSeeker",
+			newReader: func(t *testing.T) MultiReadSeeker {
+				var rs []io.ReadSeeker
+				for _, c := range contents {
+					rs = append(rs, strings.NewReader(c))
+				}
+				r, err := NewMultiReadSeeker(rs...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return r
+			},
+		}, {
+			name: "NewMultiFileReader",
+			newReader: func(t *testing.T) MultiReadSeeker {
+				r := mustNewMultiFileReader(t, filenames...)
+				t.Cleanup(func() { r.Close() })
+				return r
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			io2test.TestReader(t, func(t *testing.T) io.Reader {
+				return test.newReader(t)
+			}, content)
+			io2test.TestSeeker(t, func(t *testing.T) io.Seeker {
+				return test.newReader(t)
+			}, content)
+		})
+	}
+	t.Run("NewMultiReader", func(t *testing.T) {
+		io2test.TestReader(t, func(t *testing.T) io.Reader {
+			var rs []io.Reader
+			for _, c := range contents {
+				rs = append(rs, strings.NewReader(c))
+			}
+			// The Seek of NewMultiReader does not know the lengths of the readers.
+			return struct{ io.Reader }{NewMultiReader(rs...)}
+		}, content)
+	})
+	t.Run("NewMultiFileReader", func(t *testing.T) {
+		io2test.TestCloser(t, func(t *testing.T) io.Closer {
+			return mustNewMultiFileReader(t, filenames...)
+		}, false)
+	})
+}
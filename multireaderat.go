@@ -0,0 +1,78 @@
+package io2
+
+import (
+	"errors"
+	"io"
+	"sort"
+)
+
+// SizeReaderAt is the interface that groups the ReadAt and Size methods.
+// *io.SectionReader, *bytes.Reader and *strings.Reader implement it.
+type SizeReaderAt interface {
+	io.ReaderAt
+	// Size returns the number of bytes that can be read with ReadAt.
+	Size() int64
+}
+
+type multiReaderAt struct {
+	rs []SizeReaderAt
+	// starts holds the offsets of the readers in the concatenation.
+	starts []int64
+	size   int64
+}
+
+// NewMultiReaderAt returns a SizeReaderAt that's the logical concatenation of
+// the provided readers. A ReadAt that spans the readers is split across them.
+// It is safe for concurrent use if the provided readers are. To use an *os.File,
+// wrap it with io.NewSectionReader.
+func NewMultiReaderAt(rs ...SizeReaderAt) SizeReaderAt {
+	starts := make([]int64, len(rs))
+	size := int64(0)
+	for i, r := range rs {
+		starts[i] = size
+		size += r.Size()
+	}
+	return &multiReaderAt{rs: rs, starts: starts, size: size}
+}
+
+// Size returns the total size of the readers.
+func (mr *multiReaderAt) Size() int64 {
+	return mr.size
+}
+
+// ReadAt reads len(p) bytes from the offset off of the concatenation.
+func (mr *multiReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+	if off >= mr.size {
+		return 0, io.EOF
+	}
+	// i is the last reader that starts at or before off.
+	i := sort.Search(len(mr.starts), func(k int) bool { return mr.starts[k] > off }) - 1
+	total := 0
+	for ; i < len(mr.rs) && total < len(p); i++ {
+		r := mr.rs[i]
+		roff := off + int64(total) - mr.starts[i]
+		want := len(p) - total
+		if rest := r.Size() - roff; int64(want) > rest {
+			want = int(rest)
+		}
+		if want <= 0 {
+			continue
+		}
+		n, err := r.ReadAt(p[total:total+want], roff)
+		total += n
+		if n == want {
+			continue
+		}
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return total, err
+	}
+	if total < len(p) {
+		return total, io.EOF
+	}
+	return total, nil
+}
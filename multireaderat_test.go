@@ -0,0 +1,178 @@
+package io2
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jarxorg/io2/io2test"
+)
+
+func newTestMultiReaderAt(strs ...string) SizeReaderAt {
+	rs := make([]SizeReaderAt, len(strs))
+	for i, str := range strs {
+		rs[i] = strings.NewReader(str)
+	}
+	return NewMultiReaderAt(rs...)
+}
+
+func TestMultiReaderAt(t *testing.T) {
+	r := newTestMultiReaderAt("abc", "", "def", "g", "", "hi")
+
+	tests := []struct {
+		off    int64
+		n      int
+		want   string
+		errstr string
+	}{
+		{off: 0, n: 3, want: "abc"},
+		{off: 1, n: 4, want: "bcde"},
+		{off: 2, n: 5, want: "cdefg"},
+		{off: 6, n: 3, want: "ghi"},
+		{off: 0, n: 9, want: "abcdefghi"},
+		{off: 7, n: 3, want: "hi", errstr: "EOF"},
+		{off: 9, n: 1, want: "", errstr: "EOF"},
+		{off: -1, n: 1, errstr: "negative offset"},
+	}
+	if r.Size() != 9 {
+		t.Errorf("size %d; want %d", r.Size(), 9)
+	}
+	for i, test := range tests {
+		p := make([]byte, test.n)
+		n, err := r.ReadAt(p, test.off)
+		if test.errstr != "" {
+			if err == nil || err.Error() != test.errstr {
+				t.Errorf("tests[%d] error %v; want %s", i, err, test.errstr)
+			}
+		} else if err != nil {
+			t.Errorf("tests[%d] error %v", i, err)
+		}
+		if got := string(p[:n]); got != test.want {
+			t.Errorf("tests[%d] read %q; want %q", i, got, test.want)
+		}
+	}
+}
+
+type shortReaderAt struct {
+	*strings.Reader
+	err error
+}
+
+func (r shortReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	n, _ := r.Reader.ReadAt(p[:len(p)-1], off)
+	return n, r.err
+}
+
+func TestMultiReaderAt_Error(t *testing.T) {
+	wantErr := errors.New("test")
+	tests := []struct {
+		err  error
+		want error
+	}{
+		{err: wantErr, want: wantErr},
+		{err: io.EOF, want: io.ErrUnexpectedEOF},
+		{err: nil, want: io.ErrUnexpectedEOF},
+	}
+	for i, test := range tests {
+		r := NewMultiReaderAt(strings.NewReader("abc"), shortReaderAt{strings.NewReader("def"), test.err})
+		p := make([]byte, 6)
+		n, err := r.ReadAt(p, 1)
+		if err != test.want {
+			t.Errorf("tests[%d] error %v; want %v", i, err, test.want)
+		}
+		if got := string(p[:n]); got != "bcde" {
+			t.Errorf("tests[%d] read %q; want %q", i, got, "bcde")
+		}
+	}
+}
+
+func TestMultiReaderAt_Concurrent(t *testing.T) {
+	content := strings.Repeat("0123456789", 100)
+	strs := make([]string, 0, len(content)/7+1)
+	for s := content; len(s) > 0; {
+		n := 7
+		if n > len(s) {
+			n = len(s)
+		}
+		strs = append(strs, s[:n])
+		s = s[n:]
+	}
+	r := newTestMultiReaderAt(strs...)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			p := make([]byte, 13)
+			for off := w; off+len(p) <= len(content); off += 8 {
+				if _, err := r.ReadAt(p, int64(off)); err != nil {
+					t.Error(err)
+					return
+				}
+				if string(p) != content[off:off+len(p)] {
+					t.Errorf("read at %d %q; want %q", off, p, content[off:off+len(p)])
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+}
+
+func TestMultiReaderAt_Zip(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range []string{"a.txt", "b.txt"} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(strings.Repeat(name, 100)))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data := buf.Bytes()
+	third := len(data) / 3
+	r := NewMultiReaderAt(
+		bytes.NewReader(data[:third]),
+		bytes.NewReader(data[third:2*third]),
+		io.NewSectionReader(bytes.NewReader(data[2*third:]), 0, int64(len(data)-2*third)),
+	)
+	zr, err := zip.NewReader(r, r.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := strings.Repeat(f.Name, 100); string(got) != want {
+			t.Errorf("files[%d] %s is broken", i, f.Name)
+		}
+	}
+}
+
+func TestMultiReaderAt_Contract(t *testing.T) {
+	content := []byte("Hello, World!")
+	io2test.TestReaderAt(t, func(t *testing.T) io.ReaderAt {
+		return newTestMultiReaderAt("Hel", "", "lo, W", "orld!")
+	}, content)
+	io2test.TestReader(t, func(t *testing.T) io.Reader {
+		r := newTestMultiReaderAt("Hel", "", "lo, W", "orld!")
+		return io.NewSectionReader(r, 0, r.Size())
+	}, content)
+}
@@ -0,0 +1,179 @@
+package io2
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// OSFS implements WritableFS using the files of the operating system under the directory.
+type OSFS struct {
+	dir string
+}
+
+var (
+	_ WritableFS    = (*OSFS)(nil)
+	_ fs.StatFS     = (*OSFS)(nil)
+	_ fs.ReadDirFS  = (*OSFS)(nil)
+	_ fs.ReadFileFS = (*OSFS)(nil)
+)
+
+// NewOSFS returns a OSFS for the tree of files rooted at the directory dir.
+func NewOSFS(dir string) *OSFS {
+	return &OSFS{dir: dir}
+}
+
+func (fsys *OSFS) join(op, name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return filepath.Join(fsys.dir, filepath.FromSlash(name)), nil
+}
+
+// relErr rewrites the OS paths of err to the names in fsys, as os.DirFS does.
+func (fsys *OSFS) relErr(err error) error {
+	switch e := err.(type) {
+	case *fs.PathError:
+		e.Path = fsys.rel(e.Path)
+	case *os.LinkError:
+		e.Old = fsys.rel(e.Old)
+		e.New = fsys.rel(e.New)
+	}
+	return err
+}
+
+func (fsys *OSFS) rel(fullname string) string {
+	rel, err := filepath.Rel(fsys.dir, fullname)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fullname
+	}
+	return filepath.ToSlash(rel)
+}
+
+// Open opens the named file for reading.
+func (fsys *OSFS) Open(name string) (fs.File, error) {
+	fullname, err := fsys.join("open", name)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(fullname)
+	if err != nil {
+		return nil, fsys.relErr(err)
+	}
+	return f, nil
+}
+
+// Stat returns a FileInfo describing the named file.
+func (fsys *OSFS) Stat(name string) (fs.FileInfo, error) {
+	fullname, err := fsys.join("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(fullname)
+	if err != nil {
+		return nil, fsys.relErr(err)
+	}
+	return info, nil
+}
+
+// ReadDir reads the named directory and returns a list of directory entries sorted by filename.
+func (fsys *OSFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	fullname, err := fsys.join("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(fullname)
+	return entries, fsys.relErr(err)
+}
+
+// ReadFile reads the named file and returns its contents.
+func (fsys *OSFS) ReadFile(name string) ([]byte, error) {
+	fullname, err := fsys.join("readfile", name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(fullname)
+	return data, fsys.relErr(err)
+}
+
+// OpenFile opens the named file with the flag and perm using os.OpenFile.
+func (fsys *OSFS) OpenFile(name string, flag int, perm fs.FileMode) (WriterFile, error) {
+	fullname, err := fsys.join("open", name)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(fullname, flag, perm)
+	if err != nil {
+		return nil, fsys.relErr(err)
+	}
+	return f, nil
+}
+
+// Mkdir creates a new directory using os.Mkdir.
+func (fsys *OSFS) Mkdir(name string, perm fs.FileMode) error {
+	fullname, err := fsys.join("mkdir", name)
+	if err != nil {
+		return err
+	}
+	return fsys.relErr(os.Mkdir(fullname, perm))
+}
+
+// MkdirAll creates a directory along with any necessary parents using os.MkdirAll.
+func (fsys *OSFS) MkdirAll(name string, perm fs.FileMode) error {
+	fullname, err := fsys.join("mkdir", name)
+	if err != nil {
+		return err
+	}
+	return fsys.relErr(os.MkdirAll(fullname, perm))
+}
+
+// Remove removes the named file or empty directory using os.Remove.
+func (fsys *OSFS) Remove(name string) error {
+	fullname, err := fsys.join("remove", name)
+	if err != nil {
+		return err
+	}
+	return fsys.relErr(os.Remove(fullname))
+}
+
+// RemoveAll removes name and any children it contains using os.RemoveAll.
+func (fsys *OSFS) RemoveAll(name string) error {
+	fullname, err := fsys.join("remove", name)
+	if err != nil {
+		return err
+	}
+	return fsys.relErr(os.RemoveAll(fullname))
+}
+
+// Rename renames oldname to newname using os.Rename.
+func (fsys *OSFS) Rename(oldname, newname string) error {
+	oldfull, err := fsys.join("rename", oldname)
+	if err != nil {
+		return err
+	}
+	newfull, err := fsys.join("rename", newname)
+	if err != nil {
+		return err
+	}
+	return fsys.relErr(os.Rename(oldfull, newfull))
+}
+
+// Chmod changes the mode of the named file using os.Chmod.
+func (fsys *OSFS) Chmod(name string, mode fs.FileMode) error {
+	fullname, err := fsys.join("chmod", name)
+	if err != nil {
+		return err
+	}
+	return fsys.relErr(os.Chmod(fullname, mode))
+}
+
+// Chtimes changes the access and modification times of the named file using os.Chtimes.
+func (fsys *OSFS) Chtimes(name string, atime, mtime time.Time) error {
+	fullname, err := fsys.join("chtimes", name)
+	if err != nil {
+		return err
+	}
+	return fsys.relErr(os.Chtimes(fullname, atime, mtime))
+}
@@ -0,0 +1,604 @@
+package io2
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// WhiteoutPrefix is the prefix of whiteout markers. A file named
+	// WhiteoutPrefix + name in a layer of OverlayFS hides name in the lower layers.
+	WhiteoutPrefix = ".wh."
+	// OpaqueWhiteout is the opaque marker. A directory that contains OpaqueWhiteout
+	// in a layer of OverlayFS hides the contents of the directory in the lower layers.
+	OpaqueWhiteout = WhiteoutPrefix + WhiteoutPrefix + ".opq"
+)
+
+// OverlayFS is a union of file systems where the upper layers shadow the lower layers,
+// analogous to MultiReader for files. The directories are merged across layers.
+//
+// If OverlayFS is created by NewWritableOverlayFS, the writes go to the upper layer.
+// The files of the lower layers are copied up before they are modified, and the
+// removed files of the lower layers are hidden by the whiteout markers.
+type OverlayFS struct {
+	upper  WritableFS
+	layers []fs.FS
+}
+
+var (
+	_ WritableFS    = (*OverlayFS)(nil)
+	_ fs.StatFS     = (*OverlayFS)(nil)
+	_ fs.ReadDirFS  = (*OverlayFS)(nil)
+	_ fs.ReadFileFS = (*OverlayFS)(nil)
+)
+
+// NewOverlayFS returns a read-only OverlayFS of the layers. The first layer is the top.
+func NewOverlayFS(layers ...fs.FS) *OverlayFS {
+	return &OverlayFS{layers: layers}
+}
+
+// NewWritableOverlayFS returns an OverlayFS that writes to upper over the lower layers.
+// The first lower layer is the top of the lower layers.
+func NewWritableOverlayFS(upper WritableFS, lowers ...fs.FS) *OverlayFS {
+	return &OverlayFS{
+		upper:  upper,
+		layers: append([]fs.FS{upper}, lowers...),
+	}
+}
+
+// overlayNode is a resolved name. layers are the indexes of the layers that
+// contribute to the name from the top.
+type overlayNode struct {
+	name   string
+	info   fs.FileInfo
+	layers []int
+}
+
+func (n *overlayNode) inLower() bool {
+	return n.layers[len(n.layers)-1] > 0
+}
+
+func (fsys *OverlayFS) exists(i int, name string) (bool, error) {
+	_, err := fs.Stat(fsys.layers[i], name)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return false, err
+}
+
+func isWhiteout(elem string) bool {
+	return strings.HasPrefix(elem, WhiteoutPrefix)
+}
+
+// lookup resolves name to the contributing layers.
+func (fsys *OverlayFS) lookup(op, name string) (*overlayNode, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	if len(fsys.layers) == 0 {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	node := &overlayNode{name: "."}
+	for i := range fsys.layers {
+		info, err := fs.Stat(fsys.layers[i], ".")
+		if err != nil {
+			return nil, &fs.PathError{Op: op, Path: name, Err: err}
+		}
+		if node.info == nil {
+			node.info = info
+		}
+		node.layers = append(node.layers, i)
+		opaque, err := fsys.exists(i, OpaqueWhiteout)
+		if err != nil {
+			return nil, &fs.PathError{Op: op, Path: name, Err: err}
+		}
+		if opaque {
+			break
+		}
+	}
+	if name == "." {
+		return node, nil
+	}
+	for _, elem := range strings.Split(name, "/") {
+		if isWhiteout(elem) {
+			return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		if !node.info.IsDir() {
+			return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		child := &overlayNode{name: path.Join(node.name, elem)}
+		for _, i := range node.layers {
+			info, err := fs.Stat(fsys.layers[i], child.name)
+			if err == nil {
+				if child.info == nil {
+					child.info = info
+				} else if !info.IsDir() {
+					break
+				}
+				child.layers = append(child.layers, i)
+				if !info.IsDir() {
+					break
+				}
+				opaque, err := fsys.exists(i, path.Join(child.name, OpaqueWhiteout))
+				if err != nil {
+					return nil, &fs.PathError{Op: op, Path: name, Err: err}
+				}
+				if opaque {
+					break
+				}
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return nil, &fs.PathError{Op: op, Path: name, Err: err}
+			}
+			whiteout, err := fsys.exists(i, path.Join(node.name, WhiteoutPrefix+elem))
+			if err != nil {
+				return nil, &fs.PathError{Op: op, Path: name, Err: err}
+			}
+			if whiteout {
+				break
+			}
+		}
+		if child.info == nil {
+			return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		node = child
+	}
+	return node, nil
+}
+
+func (fsys *OverlayFS) readDir(node *overlayNode) ([]fs.DirEntry, error) {
+	var entries []fs.DirEntry
+	seen := map[string]bool{}
+	for _, i := range node.layers {
+		es, err := fs.ReadDir(fsys.layers[i], node.name)
+		if err != nil {
+			return nil, err
+		}
+		var hidden []string
+		for _, e := range es {
+			name := e.Name()
+			if isWhiteout(name) {
+				hidden = append(hidden, strings.TrimPrefix(name, WhiteoutPrefix))
+				continue
+			}
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			entries = append(entries, e)
+		}
+		for _, name := range hidden {
+			seen[name] = true
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	return entries, nil
+}
+
+// Open opens the named file of the top layer that contains the name.
+// The directories are merged across the layers.
+func (fsys *OverlayFS) Open(name string) (fs.File, error) {
+	node, err := fsys.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+	if !node.info.IsDir() {
+		return fsys.layers[node.layers[0]].Open(name)
+	}
+	return &overlayDir{fsys: fsys, node: node}, nil
+}
+
+// Stat returns a FileInfo describing the named file.
+func (fsys *OverlayFS) Stat(name string) (fs.FileInfo, error) {
+	node, err := fsys.lookup("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return node.info, nil
+}
+
+// ReadDir reads the named directory merged across the layers.
+func (fsys *OverlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	node, err := fsys.lookup("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	if !node.info.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	return fsys.readDir(node)
+}
+
+// ReadFile reads the named file of the top layer that contains the name.
+func (fsys *OverlayFS) ReadFile(name string) ([]byte, error) {
+	node, err := fsys.lookup("readfile", name)
+	if err != nil {
+		return nil, err
+	}
+	return fs.ReadFile(fsys.layers[node.layers[0]], name)
+}
+
+func (fsys *OverlayFS) writable(op, name string) error {
+	if fsys.upper == nil {
+		return &fs.PathError{Op: op, Path: name, Err: fs.ErrPermission}
+	}
+	return nil
+}
+
+// copyUpPerm returns the permission of the copy of info in the upper layer. The
+// owner can always write the copy, because the lower layers are often read-only
+// like embed.FS, whose files are 0444 and directories are 0555.
+func copyUpPerm(info fs.FileInfo) fs.FileMode {
+	if info.IsDir() {
+		return info.Mode().Perm() | 0700
+	}
+	return info.Mode().Perm() | 0200
+}
+
+// copyUp copies the node and its parents to the upper layer.
+func (fsys *OverlayFS) copyUp(node *overlayNode) error {
+	if node.layers[0] == 0 {
+		return nil
+	}
+	if dir := path.Dir(node.name); dir != "." {
+		parent, err := fsys.lookup("copyup", dir)
+		if err != nil {
+			return err
+		}
+		if err := fsys.copyUp(parent); err != nil {
+			return err
+		}
+	}
+	if node.info.IsDir() {
+		if err := fsys.upper.Mkdir(node.name, copyUpPerm(node.info)); err != nil {
+			return err
+		}
+	} else {
+		data, err := fs.ReadFile(fsys.layers[node.layers[0]], node.name)
+		if err != nil {
+			return err
+		}
+		if err := WriteFile(fsys.upper, node.name, data, copyUpPerm(node.info)); err != nil {
+			return err
+		}
+	}
+	node.layers = append([]int{0}, node.layers...)
+	return fsys.upper.Chtimes(node.name, node.info.ModTime(), node.info.ModTime())
+}
+
+// prepare copies up the parent of name and removes the whiteout marker of name
+// in the upper layer. It returns true if the whiteout marker is removed.
+func (fsys *OverlayFS) prepare(op, name string) (bool, error) {
+	dir, elem := path.Split(name)
+	dir = path.Clean(dir)
+	parent, err := fsys.lookup(op, dir)
+	if err != nil {
+		return false, err
+	}
+	if !parent.info.IsDir() {
+		return false, &fs.PathError{Op: op, Path: name, Err: errors.New("not a directory")}
+	}
+	if err := fsys.copyUp(parent); err != nil {
+		return false, err
+	}
+	whiteout := path.Join(dir, WhiteoutPrefix+elem)
+	if err := fsys.upper.Remove(whiteout); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// hide creates the whiteout marker of name in the upper layer if name is
+// still visible from the lower layers.
+func (fsys *OverlayFS) hide(name string) error {
+	if _, err := fsys.lookup("remove", name); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	dir, elem := path.Split(name)
+	dir = path.Clean(dir)
+	parent, err := fsys.lookup("remove", dir)
+	if err != nil {
+		return err
+	}
+	if err := fsys.copyUp(parent); err != nil {
+		return err
+	}
+	return WriteFile(fsys.upper, path.Join(dir, WhiteoutPrefix+elem), nil, 0644)
+}
+
+// OpenFile opens the named file with the flag and perm. If the flag is for
+// writing, the file of the lower layers is copied up to the upper layer.
+func (fsys *OverlayFS) OpenFile(name string, flag int, perm fs.FileMode) (WriterFile, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND) == 0 {
+		f, err := fsys.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		if w, ok := f.(WriterFile); ok {
+			return w, nil
+		}
+		return readOnlyFile{File: f, name: name}, nil
+	}
+	if err := fsys.writable("open", name); err != nil {
+		return nil, err
+	}
+	node, err := fsys.lookup("open", name)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) || flag&os.O_CREATE == 0 {
+			return nil, err
+		}
+		if _, err := fsys.prepare("open", name); err != nil {
+			return nil, err
+		}
+		return fsys.upper.OpenFile(name, flag, perm)
+	}
+	if flag&(os.O_CREATE|os.O_EXCL) == os.O_CREATE|os.O_EXCL {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrExist}
+	}
+	if node.layers[0] != 0 {
+		if node.info.IsDir() {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: errors.New("is a directory")}
+		}
+		if flag&os.O_TRUNC != 0 {
+			if _, err := fsys.prepare("open", name); err != nil {
+				return nil, err
+			}
+			flag |= os.O_CREATE
+			perm = copyUpPerm(node.info)
+		} else if err := fsys.copyUp(node); err != nil {
+			return nil, err
+		}
+	}
+	return fsys.upper.OpenFile(name, flag, perm)
+}
+
+// Mkdir creates a new directory in the upper layer.
+func (fsys *OverlayFS) Mkdir(name string, perm fs.FileMode) error {
+	if err := fsys.writable("mkdir", name); err != nil {
+		return err
+	}
+	if _, err := fsys.lookup("mkdir", name); err == nil {
+		return &fs.PathError{Op: "mkdir", Path: name, Err: fs.ErrExist}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	removed, err := fsys.prepare("mkdir", name)
+	if err != nil {
+		return err
+	}
+	if err := fsys.upper.Mkdir(name, perm); err != nil {
+		return err
+	}
+	if removed {
+		// NOTE: hide the contents of the removed directory in the lower layers.
+		return WriteFile(fsys.upper, path.Join(name, OpaqueWhiteout), nil, 0644)
+	}
+	return nil
+}
+
+// MkdirAll creates a directory named path, along with any necessary parents.
+func (fsys *OverlayFS) MkdirAll(name string, perm fs.FileMode) error {
+	if err := fsys.writable("mkdir", name); err != nil {
+		return err
+	}
+	if !fs.ValidPath(name) {
+		return &fs.PathError{Op: "mkdir", Path: name, Err: fs.ErrInvalid}
+	}
+	if name == "." {
+		return nil
+	}
+	dir := "."
+	for _, elem := range strings.Split(name, "/") {
+		dir = path.Join(dir, elem)
+		node, err := fsys.lookup("mkdir", dir)
+		if err == nil {
+			if !node.info.IsDir() {
+				return &fs.PathError{Op: "mkdir", Path: name, Err: errors.New("not a directory")}
+			}
+			continue
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		if err := fsys.Mkdir(dir, perm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Remove removes the named file or empty directory. The file of the lower layers
+// is hidden by the whiteout marker.
+func (fsys *OverlayFS) Remove(name string) error {
+	if err := fsys.writable("remove", name); err != nil {
+		return err
+	}
+	node, err := fsys.lookup("remove", name)
+	if err != nil {
+		return err
+	}
+	if name == "." {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrInvalid}
+	}
+	if node.info.IsDir() {
+		entries, err := fsys.readDir(node)
+		if err != nil {
+			return err
+		}
+		if len(entries) > 0 {
+			return &fs.PathError{Op: "remove", Path: name, Err: errors.New("directory not empty")}
+		}
+	}
+	return fsys.removeAll(node)
+}
+
+func (fsys *OverlayFS) removeAll(node *overlayNode) error {
+	if node.layers[0] == 0 {
+		if err := fsys.upper.RemoveAll(node.name); err != nil {
+			return err
+		}
+	}
+	if node.inLower() {
+		return fsys.hide(node.name)
+	}
+	return nil
+}
+
+// RemoveAll removes name and any children it contains. The files of the lower
+// layers are hidden by the whiteout marker.
+func (fsys *OverlayFS) RemoveAll(name string) error {
+	if err := fsys.writable("remove", name); err != nil {
+		return err
+	}
+	node, err := fsys.lookup("remove", name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if name == "." {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrInvalid}
+	}
+	return fsys.removeAll(node)
+}
+
+// Rename renames (moves) oldname to newname in the upper layer. The files of
+// the lower layers are copied up. The directories of the lower layers can not
+// be renamed.
+func (fsys *OverlayFS) Rename(oldname, newname string) error {
+	linkErr := func(err error) error {
+		var pathErr *fs.PathError
+		if errors.As(err, &pathErr) {
+			err = pathErr.Err
+		}
+		return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: err}
+	}
+	if err := fsys.writable("rename", oldname); err != nil {
+		return linkErr(err)
+	}
+	node, err := fsys.lookup("rename", oldname)
+	if err != nil {
+		return linkErr(err)
+	}
+	if node.info.IsDir() && node.inLower() {
+		return linkErr(ErrNotImplemented)
+	}
+	if dst, err := fsys.lookup("rename", newname); err == nil && dst.info.IsDir() {
+		return linkErr(fs.ErrExist)
+	}
+	if err := fsys.copyUp(node); err != nil {
+		return linkErr(err)
+	}
+	if _, err := fsys.prepare("rename", newname); err != nil {
+		return linkErr(err)
+	}
+	if err := fsys.upper.Rename(oldname, newname); err != nil {
+		return err
+	}
+	if node.inLower() {
+		return fsys.hide(oldname)
+	}
+	return nil
+}
+
+// Chmod changes the mode of the named file in the upper layer.
+func (fsys *OverlayFS) Chmod(name string, mode fs.FileMode) error {
+	if err := fsys.writable("chmod", name); err != nil {
+		return err
+	}
+	node, err := fsys.lookup("chmod", name)
+	if err != nil {
+		return err
+	}
+	if err := fsys.copyUp(node); err != nil {
+		return err
+	}
+	return fsys.upper.Chmod(name, mode)
+}
+
+// Chtimes changes the access and modification times of the named file in the upper layer.
+func (fsys *OverlayFS) Chtimes(name string, atime, mtime time.Time) error {
+	if err := fsys.writable("chtimes", name); err != nil {
+		return err
+	}
+	node, err := fsys.lookup("chtimes", name)
+	if err != nil {
+		return err
+	}
+	if err := fsys.copyUp(node); err != nil {
+		return err
+	}
+	return fsys.upper.Chtimes(name, atime, mtime)
+}
+
+// readOnlyFile implements WriterFile for the files of the read-only layers.
+type readOnlyFile struct {
+	fs.File
+	name string
+}
+
+func (f readOnlyFile) Write(p []byte) (int, error) {
+	return 0, &fs.PathError{Op: "write", Path: f.name, Err: fs.ErrPermission}
+}
+
+// overlayDir is an opened directory of OverlayFS.
+type overlayDir struct {
+	fsys    *OverlayFS
+	node    *overlayNode
+	entries []fs.DirEntry
+	off     int
+}
+
+var _ fs.ReadDirFile = (*overlayDir)(nil)
+
+func (d *overlayDir) Stat() (fs.FileInfo, error) {
+	return d.node.info, nil
+}
+
+func (d *overlayDir) Read(p []byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.node.name, Err: errors.New("is a directory")}
+}
+
+func (d *overlayDir) ReadDir(count int) ([]fs.DirEntry, error) {
+	if d.entries == nil {
+		entries, err := d.fsys.readDir(d.node)
+		if err != nil {
+			return nil, err
+		}
+		d.entries = entries
+	}
+	rest := d.entries[d.off:]
+	if count <= 0 {
+		d.off += len(rest)
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if count > len(rest) {
+		count = len(rest)
+	}
+	d.off += count
+	return rest[:count], nil
+}
+
+func (d *overlayDir) Close() error {
+	return nil
+}
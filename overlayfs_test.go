@@ -0,0 +1,215 @@
+package io2
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func testOverlayLayers() (fstest.MapFS, fstest.MapFS) {
+	upper := fstest.MapFS{
+		"a.txt":                  {Data: []byte("A")},
+		WhiteoutPrefix + "b.txt": {},
+		"dir/x.txt":              {Data: []byte("X")},
+		"opq/" + OpaqueWhiteout:  {},
+		"opq/u.txt":              {Data: []byte("U")},
+		"file":                   {Data: []byte("file")},
+	}
+	lower := fstest.MapFS{
+		"a.txt":     {Data: []byte("a")},
+		"b.txt":     {Data: []byte("b")},
+		"c.txt":     {Data: []byte("c")},
+		"dir/y.txt": {Data: []byte("y")},
+		"opq/l.txt": {Data: []byte("l")},
+		"file/z":    {Data: []byte("z")},
+	}
+	return upper, lower
+}
+
+func overlayNames(t *testing.T, fsys fs.FS, dir string) []string {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func TestOverlayFS(t *testing.T) {
+	upper, lower := testOverlayLayers()
+	fsys := NewOverlayFS(upper, lower)
+
+	if err := fstest.TestFS(fsys, "a.txt", "c.txt", "dir/x.txt", "dir/y.txt", "opq/u.txt", "file"); err != nil {
+		t.Fatal(err)
+	}
+
+	dirs := []struct {
+		dir  string
+		want []string
+	}{
+		{dir: ".", want: []string{"a.txt", "c.txt", "dir", "file", "opq"}},
+		{dir: "dir", want: []string{"x.txt", "y.txt"}},
+		{dir: "opq", want: []string{"u.txt"}},
+	}
+	for _, d := range dirs {
+		if got := overlayNames(t, fsys, d.dir); !reflect.DeepEqual(got, d.want) {
+			t.Errorf("ReadDir(%s) %v; want %v", d.dir, got, d.want)
+		}
+	}
+
+	files := []struct {
+		name string
+		want string
+		err  error
+	}{
+		{name: "a.txt", want: "A"},
+		{name: "c.txt", want: "c"},
+		{name: "dir/y.txt", want: "y"},
+		{name: "b.txt", err: fs.ErrNotExist},
+		{name: "opq/l.txt", err: fs.ErrNotExist},
+		{name: "file/z", err: fs.ErrNotExist},
+		{name: WhiteoutPrefix + "b.txt", err: fs.ErrNotExist},
+	}
+	for _, f := range files {
+		got, err := fs.ReadFile(fsys, f.name)
+		if f.err != nil {
+			if !errors.Is(err, f.err) {
+				t.Errorf("ReadFile(%s) error %v; want %v", f.name, err, f.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != f.want {
+			t.Errorf("ReadFile(%s) %s; want %s", f.name, got, f.want)
+		}
+	}
+
+	if err := fsys.Mkdir("new", 0755); !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("Mkdir on read-only error %v; want %v", err, fs.ErrPermission)
+	}
+	if _, err := fsys.OpenFile("a.txt", os.O_RDONLY, 0); err != nil {
+		t.Errorf("OpenFile read-only: %v", err)
+	}
+}
+
+func TestOverlayFS_Writable(t *testing.T) {
+	testWritableFS(t, NewWritableOverlayFS(NewMemFS()))
+
+	_, lower := testOverlayLayers()
+	testWritableFS(t, NewWritableOverlayFS(NewMemFS(), lower))
+}
+
+func TestOverlayFS_CopyUpReadOnlyLower(t *testing.T) {
+	// The modes of embed.FS.
+	lower := fstest.MapFS{
+		"assets":         {Mode: fs.ModeDir | 0555},
+		"assets/app.css": {Data: []byte("app"), Mode: 0444},
+	}
+	upper := NewOSFS(t.TempDir())
+	fsys := NewWritableOverlayFS(upper, lower)
+
+	f, err := fsys.OpenFile("assets/app.css", os.O_RDWR, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	if err := WriteFile(fsys, "assets/new.css", []byte("new"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		want fs.FileMode
+	}{
+		{name: "assets", want: fs.ModeDir | 0755},
+		{name: "assets/app.css", want: 0644},
+	}
+	for i, test := range tests {
+		info, err := upper.Stat(test.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode() != test.want {
+			t.Errorf("tests[%d] %s mode %v; want %v", i, test.name, info.Mode(), test.want)
+		}
+	}
+}
+
+func TestOverlayFS_CopyUp(t *testing.T) {
+	_, lower := testOverlayLayers()
+	upper := NewMemFS()
+	fsys := NewWritableOverlayFS(upper, lower)
+
+	f, err := fsys.OpenFile("dir/y.txt", os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("Y")); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	if got, _ := fs.ReadFile(fsys, "dir/y.txt"); string(got) != "yY" {
+		t.Errorf("copied up %s; want %s", got, "yY")
+	}
+	if got, _ := fs.ReadFile(upper, "dir/y.txt"); string(got) != "yY" {
+		t.Errorf("upper %s; want %s", got, "yY")
+	}
+	if string(lower["dir/y.txt"].Data) != "y" {
+		t.Errorf("lower is modified")
+	}
+
+	if err := fsys.Remove("c.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fs.Stat(fsys, "c.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("removed c.txt exists: %v", err)
+	}
+	if err := WriteFile(fsys, "c.txt", []byte("C"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := fs.ReadFile(fsys, "c.txt"); string(got) != "C" {
+		t.Errorf("recreated %s; want %s", got, "C")
+	}
+
+	if err := fsys.RemoveAll("dir"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fsys.Mkdir("dir", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if got := overlayNames(t, fsys, "dir"); len(got) != 0 {
+		t.Errorf("recreated dir has %v", got)
+	}
+
+	if err := fsys.Rename("a.txt", "opq/a.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if got := overlayNames(t, fsys, "opq"); !reflect.DeepEqual(got, []string{"a.txt", "l.txt"}) {
+		t.Errorf("renamed %v", got)
+	}
+	if _, err := fs.Stat(fsys, "a.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("renamed a.txt exists: %v", err)
+	}
+	if err := fsys.Rename("file", "moved"); !errors.Is(err, ErrNotImplemented) {
+		t.Errorf("Rename directory of the lower error %v; want %v", err, ErrNotImplemented)
+	}
+
+	if err := fsys.Chmod("b.txt", 0600); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := upper.Stat("b.txt"); info == nil || info.Mode() != 0600 {
+		t.Errorf("Chmod does not copy up")
+	}
+
+	if err := fsys.Remove("opq"); err == nil {
+		t.Errorf("Remove of a non-empty directory returns no error")
+	}
+}
@@ -0,0 +1,80 @@
+package io2
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// DefaultPoolMaxCapacity is the maximum capacity of the buffers retained by
+// WriteSeekBufferPool when the given maximum is not positive.
+const DefaultPoolMaxCapacity = 1 << 20
+
+// minPoolClass is the capacity of the smallest size class, 512 bytes.
+const minPoolClass = 9
+
+// WriteSeekBufferPool is a set of pools of WriteSeekBuffers by size class. The
+// size classes are powers of two. WriteSeekBufferPool is safe for concurrent use.
+type WriteSeekBufferPool struct {
+	max   int
+	pools []sync.Pool
+}
+
+// NewWriteSeekBufferPool returns a WriteSeekBufferPool that retains buffers up
+// to maxCapacity bytes. If maxCapacity is not positive, DefaultPoolMaxCapacity is used.
+func NewWriteSeekBufferPool(maxCapacity int) *WriteSeekBufferPool {
+	if maxCapacity <= 0 {
+		maxCapacity = DefaultPoolMaxCapacity
+	}
+	n := 1
+	if c := bits.Len(uint(maxCapacity)) - 1; c > minPoolClass {
+		n += c - minPoolClass
+	}
+	return &WriteSeekBufferPool{
+		max:   maxCapacity,
+		pools: make([]sync.Pool, n),
+	}
+}
+
+// Get returns an empty WriteSeekBuffer with a capacity of at least sizeHint bytes.
+func (p *WriteSeekBufferPool) Get(sizeHint int) *WriteSeekBuffer {
+	class := 0
+	if sizeHint > 1<<minPoolClass {
+		class = bits.Len(uint(sizeHint-1)) - minPoolClass
+	}
+	if class >= len(p.pools) {
+		return NewWriteSeekBuffer(sizeHint)
+	}
+	if b, ok := p.pools[class].Get().(*WriteSeekBuffer); ok {
+		return b
+	}
+	return &WriteSeekBuffer{
+		s: &bytesStore{buf: make([]byte, 0, 1<<(minPoolClass+class)), pool: p},
+	}
+}
+
+// Put resets b and adds it to the pool. Only buffers returned by Get of p are
+// retained; other buffers, buffers grown beyond the maximum capacity and
+// buffers shared with a Snapshot are dropped without being closed. b must not
+// be used after Put.
+func (p *WriteSeekBufferPool) Put(b *WriteSeekBuffer) {
+	s, ok := b.s.(*bytesStore)
+	if !ok || s.pool != p {
+		return
+	}
+	c := cap(s.buf)
+	if s.shared || c > p.max {
+		return
+	}
+	class := bits.Len(uint(c)) - 1 - minPoolClass
+	if class >= len(p.pools) {
+		class = len(p.pools) - 1
+	}
+	s.buf = s.buf[:0]
+	// Keep the extents to reuse their capacity.
+	*b = WriteSeekBuffer{
+		s:     s,
+		dirty: dirtyState{extents: b.dirty.extents[:0]},
+		data:  b.data[:0],
+	}
+	p.pools[class].Put(b)
+}
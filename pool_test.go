@@ -0,0 +1,106 @@
+package io2
+
+import (
+	"io"
+	"testing"
+)
+
+func TestWriteSeekBufferPool_Get(t *testing.T) {
+	p := NewWriteSeekBufferPool(4096)
+
+	tests := []struct {
+		sizeHint int
+		wantCap  int
+	}{
+		{sizeHint: 0, wantCap: 512},
+		{sizeHint: 512, wantCap: 512},
+		{sizeHint: 513, wantCap: 1024},
+		{sizeHint: 4096, wantCap: 4096},
+		{sizeHint: 5000, wantCap: 5000},
+	}
+	for i, test := range tests {
+		b := p.Get(test.sizeHint)
+		if b.Len() != 0 || b.Offset() != 0 {
+			t.Errorf("tests[%d] len %d off %d; want empty", i, b.Len(), b.Offset())
+		}
+		if got := cap(b.s.(*bytesStore).buf); got != test.wantCap {
+			t.Errorf("tests[%d] cap %d; want %d", i, got, test.wantCap)
+		}
+	}
+}
+
+func TestWriteSeekBufferPool_Put(t *testing.T) {
+	p := NewWriteSeekBufferPool(4096)
+
+	// sync.Pool may drop the buffer, so try several times.
+	reused := false
+	for i := 0; i < 10 && !reused; i++ {
+		b := p.Get(1000)
+		b.Write(make([]byte, 100))
+		b.Seek(10, io.SeekStart)
+		b.SetMaxSize(200)
+		b.Truncate(50)
+		s := b.s
+		p.Put(b)
+
+		b = p.Get(1000)
+		if b.s != s {
+			continue
+		}
+		reused = true
+		if b.Len() != 0 || b.Offset() != 0 || b.MaxSize() != 0 || b.Truncated() ||
+			len(b.DirtyExtents()) != 0 || len(b.DataExtents()) != 0 {
+			t.Errorf("reused buffer is not reset: len %d off %d max %d", b.Len(), b.Offset(), b.MaxSize())
+		}
+		b.WriteAt([]byte(`x`), 3)
+		if got, want := b.Bytes(), []byte{0, 0, 0, 'x'}; string(got) != string(want) {
+			t.Errorf("bytes %v; want %v", got, want)
+		}
+	}
+	if !reused {
+		t.Errorf("buffer is not reused")
+	}
+}
+
+func TestWriteSeekBufferPool_PutDrop(t *testing.T) {
+	p := NewWriteSeekBufferPool(1024)
+
+	large := p.Get(1024)
+	large.Write(make([]byte, 4096))
+	shared := p.Get(1024)
+	shared.Snapshot()
+	other := NewWriteSeekBufferPool(1024).Get(1024)
+	unpooled := NewWriteSeekBuffer(1024)
+	owned := NewWriteSeekBufferBytes(make([]byte, 10, 1024))
+	paged := NewPagedWriteSeekBuffer(0)
+	paged.Write([]byte(`x`))
+	spilled := NewSpillWriteSeekBuffer(0, t.TempDir())
+	defer spilled.Close()
+	spilled.Write([]byte(`x`))
+
+	for i, b := range []*WriteSeekBuffer{large, shared, other, unpooled, owned, paged, spilled} {
+		s := b.s
+		p.Put(b)
+		for j := 0; j < 10; j++ {
+			if got := p.Get(1024); got.s == s {
+				t.Fatalf("tests[%d] buffer is retained", i)
+			}
+		}
+	}
+	for i, b := range []*WriteSeekBuffer{paged, spilled} {
+		if got := string(b.Bytes()); got != "x" {
+			t.Errorf("tests[%d] bytes %q; want %q", i, got, "x")
+		}
+	}
+}
+
+func BenchmarkWriteSeekBufferPool(b *testing.B) {
+	p := NewWriteSeekBufferPool(0)
+	data := make([]byte, 8192)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf := p.Get(len(data))
+		buf.Write(data)
+		p.Put(buf)
+	}
+}
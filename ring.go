@@ -0,0 +1,201 @@
+package io2
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// RingBuffer is a fixed-capacity circular buffer. Write appends to the end and
+// overwrites the oldest data once the buffer is full. Offsets are logical: they
+// count every byte ever written, so they increase monotonically, and only the
+// window [Start(), End()) is retained. Read and Seek use a read offset that is
+// separate from the end. RingBuffer is safe for concurrent use.
+type RingBuffer struct {
+	mu  sync.Mutex
+	buf []byte
+	end int64
+	off int64
+}
+
+var (
+	_ io.ReadWriteSeeker = (*RingBuffer)(nil)
+	_ io.ReaderAt        = (*RingBuffer)(nil)
+)
+
+// NewRingBuffer returns a RingBuffer that retains the last capacity bytes.
+func NewRingBuffer(capacity int) *RingBuffer {
+	return &RingBuffer{
+		buf: make([]byte, capacity),
+	}
+}
+
+func (r *RingBuffer) start() int64 {
+	if s := r.end - int64(len(r.buf)); s > 0 {
+		return s
+	}
+	return 0
+}
+
+// Start returns the offset of the oldest retained byte.
+func (r *RingBuffer) Start() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.start()
+}
+
+// End returns the number of bytes ever written, the offset just past the newest byte.
+func (r *RingBuffer) End() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.end
+}
+
+// Len returns the number of retained bytes.
+func (r *RingBuffer) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return int(r.end - r.start())
+}
+
+// Cap returns the capacity of the buffer.
+func (r *RingBuffer) Cap() int {
+	return len(r.buf)
+}
+
+// Write appends the contents of p to the end, overwriting the oldest data as
+// needed. The return value n is the length of p; err is always nil.
+func (r *RingBuffer) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := len(p)
+	if len(r.buf) == 0 {
+		r.end += int64(n)
+		return n, nil
+	}
+	if over := len(p) - len(r.buf); over > 0 {
+		r.end += int64(over)
+		p = p[over:]
+	}
+	i := int(r.end % int64(len(r.buf)))
+	m := copy(r.buf[i:], p)
+	copy(r.buf, p[m:])
+	r.end += int64(len(p))
+	return n, nil
+}
+
+// readAt copies the retained bytes from off into p. off must be in the window.
+func (r *RingBuffer) readAt(p []byte, off int64) int {
+	if off >= r.end {
+		return 0
+	}
+	if rest := r.end - off; int64(len(p)) > rest {
+		p = p[:rest]
+	}
+	i := int(off % int64(len(r.buf)))
+	n := copy(p, r.buf[i:])
+	n += copy(p[n:], r.buf)
+	return n
+}
+
+// ReadAt reads len(p) bytes from the offset off. If off is before Start(), ReadAt
+// returns ErrEvicted. If ReadAt reads fewer than len(p) bytes, it returns io.EOF.
+func (r *RingBuffer) ReadAt(p []byte, off int64) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+	if off < r.start() {
+		return 0, ErrEvicted
+	}
+	n := r.readAt(p, off)
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Read reads from the read offset. If the data at the read offset has been
+// overwritten, Read returns ErrEvicted; Seek to Start() to continue.
+func (r *RingBuffer) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.off < r.start() {
+		return 0, ErrEvicted
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if r.off >= r.end {
+		return 0, io.EOF
+	}
+	n := r.readAt(p, r.off)
+	r.off += int64(n)
+	return n, nil
+}
+
+// Seek sets the read offset to offset, interpreted according to whence:
+// SeekStart means relative to the first byte ever written, SeekCurrent means
+// relative to the read offset, and SeekEnd means relative to End(). Seeking
+// before Start() returns ErrEvicted.
+func (r *RingBuffer) Seek(offset int64, whence int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var noff int64
+	switch whence {
+	case io.SeekStart:
+		noff = offset
+	case io.SeekCurrent:
+		noff = r.off + offset
+	case io.SeekEnd:
+		noff = r.end + offset
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if noff < 0 {
+		return 0, errors.New("negative position")
+	}
+	if noff < r.start() {
+		return 0, ErrEvicted
+	}
+	r.off = noff
+	return noff, nil
+}
+
+// Tail returns a copy of the last n retained bytes. If n is larger than Len(),
+// Tail returns all the retained bytes.
+func (r *RingBuffer) Tail(n int) []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if l := int(r.end - r.start()); n > l {
+		n = l
+	}
+	if n < 0 {
+		n = 0
+	}
+	p := make([]byte, n)
+	r.readAt(p, r.end-int64(n))
+	return p
+}
+
+// Bytes returns a copy of the retained bytes.
+func (r *RingBuffer) Bytes() []byte {
+	return r.Tail(len(r.buf))
+}
+
+// Reset discards all the data and resets the offsets to 0.
+func (r *RingBuffer) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.end, r.off = 0, 0
+}
@@ -0,0 +1,108 @@
+package io2
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/jarxorg/io2/io2test"
+)
+
+func TestRingBuffer(t *testing.T) {
+	r := NewRingBuffer(8)
+
+	r.Write([]byte(`12345`))
+	r.Write([]byte(`6789`))
+	r.Write([]byte(`abc`))
+
+	if r.Start() != 4 || r.End() != 12 || r.Len() != 8 || r.Cap() != 8 {
+		t.Errorf("start %d end %d len %d cap %d; want 4 12 8 8", r.Start(), r.End(), r.Len(), r.Cap())
+	}
+	if got, want := string(r.Bytes()), "56789abc"; got != want {
+		t.Errorf("bytes %s; want %s", got, want)
+	}
+
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{n: 0, want: ""},
+		{n: 3, want: "abc"},
+		{n: 8, want: "56789abc"},
+		{n: 100, want: "56789abc"},
+		{n: -1, want: ""},
+	}
+	for i, test := range tests {
+		if got := string(r.Tail(test.n)); got != test.want {
+			t.Errorf("tests[%d] tail %s; want %s", i, got, test.want)
+		}
+	}
+
+	p := make([]byte, 4)
+	if _, err := r.ReadAt(p, 3); !errors.Is(err, ErrEvicted) {
+		t.Errorf("read at evicted error %v; want %v", err, ErrEvicted)
+	}
+	n, err := r.ReadAt(p, 10)
+	if err != io.EOF || string(p[:n]) != "bc" {
+		t.Errorf("read at %q %v; want %q %v", p[:n], err, "bc", io.EOF)
+	}
+
+	if _, err := r.Read(p); !errors.Is(err, ErrEvicted) {
+		t.Errorf("read evicted error %v; want %v", err, ErrEvicted)
+	}
+	if _, err := r.Seek(3, io.SeekStart); !errors.Is(err, ErrEvicted) {
+		t.Errorf("seek evicted error %v; want %v", err, ErrEvicted)
+	}
+	if _, err := r.Seek(-3, io.SeekEnd); err != nil {
+		t.Fatal(err)
+	}
+	n, err = r.Read(p)
+	if err != nil || string(p[:n]) != "abc" {
+		t.Errorf("read %q %v; want %q", p[:n], err, "abc")
+	}
+
+	r.Write([]byte(`0123456789ABCDEF`))
+	if r.End() != 28 {
+		t.Errorf("end %d; want %d", r.End(), 28)
+	}
+	if got, want := string(r.Bytes()), "89ABCDEF"; got != want {
+		t.Errorf("bytes %s; want %s", got, want)
+	}
+
+	r.Reset()
+	if r.Len() != 0 || r.End() != 0 {
+		t.Errorf("len %d end %d after reset", r.Len(), r.End())
+	}
+}
+
+func TestRingBuffer_Zero(t *testing.T) {
+	r := NewRingBuffer(0)
+	if n, err := r.Write([]byte(`abc`)); n != 3 || err != nil {
+		t.Errorf("write %d %v; want %d", n, err, 3)
+	}
+	if r.Start() != 3 || r.Len() != 0 || len(r.Tail(1)) != 0 {
+		t.Errorf("start %d len %d", r.Start(), r.Len())
+	}
+	if _, err := r.Read(make([]byte, 1)); !errors.Is(err, ErrEvicted) {
+		t.Errorf("read error %v; want %v", err, ErrEvicted)
+	}
+}
+
+func TestRingBuffer_Contract(t *testing.T) {
+	content := []byte("Hello, World!")
+	newFilled := func() *RingBuffer {
+		r := NewRingBuffer(len(content))
+		r.Write([]byte(`evicted`))
+		r.Reset()
+		r.Write(content)
+		return r
+	}
+	io2test.TestWriter(t, func(t *testing.T) io.Writer {
+		return NewRingBuffer(1024)
+	}, func(t *testing.T, w io.Writer) []byte {
+		return w.(*RingBuffer).Bytes()
+	})
+	io2test.TestSeeker(t, func(t *testing.T) io.Seeker { return newFilled() }, content)
+	io2test.TestReader(t, func(t *testing.T) io.Reader { return newFilled() }, content)
+	io2test.TestReaderAt(t, func(t *testing.T) io.ReaderAt { return newFilled() }, content)
+}
@@ -0,0 +1,147 @@
+package io2
+
+import (
+	"errors"
+	"io"
+	"sort"
+)
+
+// Seek whence values of WriteSeekBuffer for sparse contents. The values are the
+// same as SEEK_DATA and SEEK_HOLE of lseek(2) on Linux.
+const (
+	// SeekData means the next data at or after the offset.
+	SeekData = 3
+	// SeekHole means the next hole at or after the offset. The end of the
+	// buffer is treated as a hole.
+	SeekHole = 4
+)
+
+// DataExtents returns the sorted extents that have been written. The bytes
+// outside of them are holes that have never been written, and read back as zeros.
+func (b *WriteSeekBuffer) DataExtents() []Extent {
+	return append([]Extent{}, b.data...)
+}
+
+// segment returns the end of the data or hole that contains off, and whether it is a hole.
+func (b *WriteSeekBuffer) segment(off int) (int, bool) {
+	i := sort.Search(len(b.data), func(k int) bool { return b.data[k].End() > off })
+	if i == len(b.data) {
+		return b.len, true
+	}
+	if e := b.data[i]; e.Off > off {
+		return e.Off, true
+	}
+	return b.data[i].End(), false
+}
+
+func (b *WriteSeekBuffer) hasHole(off int) bool {
+	end, hole := b.segment(off)
+	return hole || end < b.len
+}
+
+func (b *WriteSeekBuffer) seekSparse(offset int64, whence int) (int64, error) {
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	off := int(offset)
+	if off >= b.len {
+		return 0, errors.New("offset past the end")
+	}
+	end, hole := b.segment(off)
+	switch {
+	case whence == SeekData && hole:
+		if end >= b.len {
+			return 0, errors.New("no data after offset")
+		}
+		off = end
+	case whence == SeekHole && !hole:
+		off = end
+	}
+	b.off = off
+	return int64(off), nil
+}
+
+// WriteSparseTo writes data from the offset to w like WriteTo, but skips the
+// holes past the end of w instead of writing zeros, so that copying to a file
+// keeps it sparse. The holes are skipped only if w implements io.WriterAt and
+// io.Seeker and accepts positional writes; otherwise, as for a pipe or a file
+// opened with os.O_APPEND, the holes are written as zeros.
+func (b *WriteSeekBuffer) WriteSparseTo(w io.Writer) (int64, error) {
+	if b.off >= b.len {
+		return 0, nil
+	}
+	wa, ok := w.(io.WriterAt)
+	if !ok || !b.hasHole(b.off) {
+		return b.writeData(w, b.len)
+	}
+	s, ok := w.(io.Seeker)
+	if !ok {
+		return b.writeData(w, b.len)
+	}
+	base, err := s.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return b.writeData(w, b.len)
+	}
+	// An empty WriteAt fails on files opened with os.O_APPEND.
+	if _, err := wa.WriteAt(nil, base); err != nil {
+		return b.writeData(w, b.len)
+	}
+	return b.writeSparse(wa, s, base)
+}
+
+// writeSparse writes the bytes from the offset to w at base, skipping the
+// holes past the end of w, and then seeks w to the end of the written bytes.
+func (b *WriteSeekBuffer) writeSparse(w io.WriterAt, s io.Seeker, base int64) (int64, error) {
+	size, err := s.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+
+	ow := &offsetWriter{w: w, off: base}
+	start := b.off
+	for b.off < b.len {
+		end, hole := b.segment(b.off)
+		if hole && ow.off < size {
+			// Write zeros over the contents of w.
+			if rest := int(size - ow.off); end > b.off+rest {
+				end = b.off + rest
+			}
+			hole = false
+		}
+		if !hole {
+			if _, err := b.writeData(ow, end); err != nil {
+				return int64(b.off - start), err
+			}
+			continue
+		}
+		last := end == b.len
+		if last {
+			// Write the last byte to extend w to the end of the hole.
+			end--
+		}
+		ow.off += int64(end - b.off)
+		b.off = end
+		if last {
+			if _, err := b.writeTo(ow, []byte{0}); err != nil {
+				return int64(b.off - start), err
+			}
+		}
+	}
+	total := int64(b.off - start)
+	if _, err := s.Seek(base+total, io.SeekStart); err != nil {
+		return total, err
+	}
+	return total, nil
+}
+
+// offsetWriter writes to w at the offset and advances it.
+type offsetWriter struct {
+	w   io.WriterAt
+	off int64
+}
+
+func (o *offsetWriter) Write(p []byte) (int, error) {
+	n, err := o.w.WriteAt(p, o.off)
+	o.off += int64(n)
+	return n, err
+}
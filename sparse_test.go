@@ -0,0 +1,181 @@
+package io2
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func newSparseBuffer() *WriteSeekBuffer {
+	// data: [4, 8) and [16, 20), length 24
+	b := NewPagedWriteSeekBuffer(4)
+	b.WriteAt([]byte(`abcd`), 4)
+	b.WriteAt([]byte(`efgh`), 16)
+	b.Seek(24, io.SeekStart)
+	b.Write([]byte(`!`))
+	b.Truncate(24)
+	return b
+}
+
+func TestDataExtents(t *testing.T) {
+	b := newSparseBuffer()
+	defer b.Close()
+
+	want := []Extent{{4, 4}, {16, 4}}
+	if got := b.DataExtents(); !reflect.DeepEqual(got, want) {
+		t.Errorf("data extents %v; want %v", got, want)
+	}
+	if got := len(b.s.(*pagedStore).pages); got != 2 {
+		t.Errorf("pages %d; want %d", got, 2)
+	}
+	if got := NewWriteSeekBufferBytes([]byte(`123`)).DataExtents(); !reflect.DeepEqual(got, []Extent{{0, 3}}) {
+		t.Errorf("data extents %v; want %v", got, []Extent{{0, 3}})
+	}
+}
+
+func TestSeekDataHole(t *testing.T) {
+	b := newSparseBuffer()
+	defer b.Close()
+
+	tests := []struct {
+		off     int64
+		whence  int
+		wantOff int64
+		errstr  string
+	}{
+		{off: 0, whence: SeekData, wantOff: 4},
+		{off: 5, whence: SeekData, wantOff: 5},
+		{off: 8, whence: SeekData, wantOff: 16},
+		{off: 20, whence: SeekData, errstr: "no data after offset"},
+		{off: 0, whence: SeekHole, wantOff: 0},
+		{off: 4, whence: SeekHole, wantOff: 8},
+		{off: 17, whence: SeekHole, wantOff: 20},
+		{off: 21, whence: SeekHole, wantOff: 21},
+		{off: 24, whence: SeekHole, errstr: "offset past the end"},
+		{off: -1, whence: SeekData, errstr: "negative position"},
+	}
+	for i, test := range tests {
+		got, err := b.Seek(test.off, test.whence)
+		if test.errstr != "" {
+			if err == nil {
+				t.Fatalf("tests[%d] no error", i)
+			}
+			if err.Error() != test.errstr {
+				t.Errorf("tests[%d] error %s; want %s", i, err.Error(), test.errstr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("tests[%d] seek: %v", i, err)
+		}
+		if got != test.wantOff {
+			t.Errorf("tests[%d] off %d; want %d", i, got, test.wantOff)
+		}
+	}
+}
+
+func TestWriteSparseTo(t *testing.T) {
+	b := newSparseBuffer()
+	defer b.Close()
+
+	name := filepath.Join(t.TempDir(), "sparse")
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// The first 6 bytes of the file must be overwritten with zeros.
+	f.Write([]byte(`xxxxxx`))
+	f.Seek(0, io.SeekStart)
+
+	b.Seek(0, io.SeekStart)
+	n, err := b.WriteSparseTo(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 24 {
+		t.Errorf("write %d; want %d", n, 24)
+	}
+	if off, _ := f.Seek(0, io.SeekCurrent); off != 24 {
+		t.Errorf("file offset %d; want %d", off, 24)
+	}
+	got, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, b.Bytes()) {
+		t.Errorf("file %q; want %q", got, b.Bytes())
+	}
+}
+
+func TestWriteSparseTo_Pipe(t *testing.T) {
+	b := newSparseBuffer()
+	defer b.Close()
+	want := b.Bytes()
+
+	tests := []func(w io.Writer) (int64, error){
+		func(w io.Writer) (int64, error) { return io.Copy(w, b) },
+		b.WriteSparseTo,
+	}
+	for i, test := range tests {
+		pr, pw, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		done := make(chan []byte)
+		go func() {
+			got, _ := ioutil.ReadAll(pr)
+			done <- got
+		}()
+		b.Seek(0, io.SeekStart)
+		n, err := test(pw)
+		pw.Close()
+		got := <-done
+		pr.Close()
+		if err != nil {
+			t.Errorf("tests[%d] %v", i, err)
+		}
+		if n != 24 {
+			t.Errorf("tests[%d] write %d; want %d", i, n, 24)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("tests[%d] got %q; want %q", i, got, want)
+		}
+	}
+}
+
+func TestWriteSparseTo_Append(t *testing.T) {
+	b := NewWriteSeekBuffer(0)
+	b.Write([]byte(`ab`))
+	b.WriteAt([]byte(`cd`), 6)
+
+	name := filepath.Join(t.TempDir(), "append")
+	if err := ioutil.WriteFile(name, []byte(`XYZ`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	b.Seek(0, io.SeekStart)
+	n, err := b.WriteSparseTo(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 8 {
+		t.Errorf("write %d; want %d", n, 8)
+	}
+	got, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "XYZab\x00\x00\x00\x00cd"; string(got) != want {
+		t.Errorf("file %q; want %q", got, want)
+	}
+}
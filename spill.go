@@ -0,0 +1,123 @@
+package io2
+
+import (
+	"io/ioutil"
+	"os"
+)
+
+// NewSpillWriteSeekBuffer returns an WriteSeekBuffer that stays in memory while
+// its length is at most threshold, and moves its contents to a temp file in dir
+// once a write crosses it. If dir is the empty string, the default directory for
+// temporary files is used. Close removes the temp file.
+func NewSpillWriteSeekBuffer(threshold int, dir string) *WriteSeekBuffer {
+	return &WriteSeekBuffer{
+		s: &spillStore{
+			threshold: threshold,
+			dir:       dir,
+			mem:       &bytesStore{},
+		},
+	}
+}
+
+// spillStore is a store on memory that switches to a temp file.
+type spillStore struct {
+	threshold int
+	dir       string
+	mem       *bytesStore
+	file      *os.File
+	// err is the error of the last truncate on the file.
+	err error
+}
+
+// spilled reports whether the contents have been moved to a temp file.
+func (s *spillStore) spilled() bool {
+	return s.file != nil
+}
+
+func (s *spillStore) spill() error {
+	f, err := ioutil.TempFile(s.dir, "io2-spill-")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(s.mem.buf); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	s.file = f
+	s.mem = nil
+	return nil
+}
+
+func (s *spillStore) readAt(p []byte, off int) error {
+	if !s.spilled() {
+		return s.mem.readAt(p, off)
+	}
+	if s.err != nil {
+		return s.err
+	}
+	_, err := s.file.ReadAt(p, int64(off))
+	return err
+}
+
+func (s *spillStore) writeAt(p []byte, off int) error {
+	if !s.spilled() {
+		if off+len(p) <= s.threshold {
+			return s.mem.writeAt(p, off)
+		}
+		if err := s.spill(); err != nil {
+			return err
+		}
+	}
+	if s.err != nil {
+		return s.err
+	}
+	_, err := s.file.WriteAt(p, int64(off))
+	return err
+}
+
+func (s *spillStore) truncate(n int) error {
+	if !s.spilled() {
+		if n <= s.threshold {
+			return s.mem.truncate(n)
+		}
+		if err := s.spill(); err != nil {
+			return err
+		}
+	}
+	if err := s.file.Truncate(int64(n)); err != nil {
+		s.err = err
+	}
+	return s.err
+}
+
+func (s *spillStore) bytes(n int) []byte {
+	if !s.spilled() {
+		return s.mem.bytes(n)
+	}
+	buf := make([]byte, n)
+	m, _ := s.file.ReadAt(buf, 0)
+	return buf[:m]
+}
+
+func (s *spillStore) snapshot(n int) store {
+	if !s.spilled() {
+		return s.mem.snapshot(n)
+	}
+	return &bytesStore{buf: s.bytes(n)}
+}
+
+// close removes the temp file and moves the store back to memory.
+func (s *spillStore) close() error {
+	if !s.spilled() {
+		return nil
+	}
+	f := s.file
+	s.file, s.err = nil, nil
+	s.mem = &bytesStore{}
+	err := f.Close()
+	if rerr := os.Remove(f.Name()); err == nil {
+		err = rerr
+	}
+	return err
+}
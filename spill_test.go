@@ -0,0 +1,112 @@
+package io2
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/jarxorg/io2/io2test"
+)
+
+func TestSpillWriteSeekBuffer(t *testing.T) {
+	dir := t.TempDir()
+	b := NewSpillWriteSeekBuffer(8, dir)
+	defer b.Close()
+
+	files := func() int {
+		infos, err := ioutil.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(infos)
+	}
+
+	b.Write([]byte(`12345678`))
+	if n := files(); n != 0 {
+		t.Errorf("files %d; want %d", n, 0)
+	}
+	b.Write([]byte(`9`))
+	if n := files(); n != 1 {
+		t.Errorf("files %d; want %d", n, 1)
+	}
+
+	b.Seek(-5, io.SeekEnd)
+	b.Write([]byte(`def`))
+	if got, want := string(b.Bytes()), "1234def89"; got != want {
+		t.Errorf("bytes %s; want %s", got, want)
+	}
+
+	b.Truncate(3)
+	b.Seek(0, io.SeekStart)
+	got, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "123"; string(got) != want {
+		t.Errorf("read %s; want %s", got, want)
+	}
+
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if n := files(); n != 0 {
+		t.Errorf("files after close %d; want %d", n, 0)
+	}
+	if err := b.Close(); err != nil {
+		t.Errorf("close twice: %v", err)
+	}
+}
+
+func TestSpillWriteSeekBuffer_Errors(t *testing.T) {
+	b := NewSpillWriteSeekBuffer(2, "/notfound")
+	defer b.Close()
+
+	if _, err := b.Write([]byte(`12`)); err != nil {
+		t.Fatalf("write below threshold: %v", err)
+	}
+	n, err := b.Write([]byte(`3`))
+	if !os.IsNotExist(err) {
+		t.Errorf("write error %v; want not exist", err)
+	}
+	if n != 0 || b.Len() != 2 || b.Offset() != 2 {
+		t.Errorf("n %d len %d off %d; want 0 2 2", n, b.Len(), b.Offset())
+	}
+}
+
+func TestSpillWriteSeekBuffer_Contract(t *testing.T) {
+	content := []byte("Hello, World!")
+	dir := t.TempDir()
+	var bufs []*WriteSeekBuffer
+	defer func() {
+		for _, b := range bufs {
+			b.Close()
+		}
+	}()
+	newBuffer := func() *WriteSeekBuffer {
+		b := NewSpillWriteSeekBuffer(4, dir)
+		bufs = append(bufs, b)
+		return b
+	}
+	newFilled := func() *WriteSeekBuffer {
+		b := newBuffer()
+		b.Write(content)
+		b.Seek(0, io.SeekStart)
+		return b
+	}
+
+	io2test.TestWriter(t, func(t *testing.T) io.Writer {
+		return newBuffer()
+	}, func(t *testing.T, w io.Writer) []byte {
+		return w.(*WriteSeekBuffer).Bytes()
+	})
+	io2test.TestWriterAt(t, func(t *testing.T) io.WriterAt {
+		return newBuffer()
+	}, func(t *testing.T, w io.WriterAt) []byte {
+		return w.(*WriteSeekBuffer).Bytes()
+	})
+	io2test.TestClampingSeeker(t, func(t *testing.T) io.Seeker { return newFilled() }, content)
+	io2test.TestReader(t, func(t *testing.T) io.Reader { return newFilled() }, content)
+	io2test.TestReaderAt(t, func(t *testing.T) io.ReaderAt { return newFilled() }, content)
+	io2test.TestCloser(t, func(t *testing.T) io.Closer { return newFilled() }, true)
+}
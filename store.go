@@ -0,0 +1,192 @@
+package io2
+
+// DefaultPageSize is the page size used by NewPagedWriteSeekBuffer when the
+// given page size is not positive.
+const DefaultPageSize = 64 * 1024
+
+// store is the backing storage of WriteSeekBuffer. The WriteSeekBuffer tracks
+// the length and never reads past it. After truncate(n), bytes past n must read
+// back as zeros when the length grows again.
+type store interface {
+	readAt(p []byte, off int) error
+	writeAt(p []byte, off int) error
+	// truncate changes the size to n. The bytes added by extending read back
+	// as zeros.
+	truncate(n int) error
+	bytes(n int) []byte
+	// snapshot returns a read-only store of the first n bytes that later
+	// writes to the store do not change.
+	snapshot(n int) store
+}
+
+// storeCloser is implemented by stores that hold resources to release on
+// WriteSeekBuffer.Close.
+type storeCloser interface {
+	close() error
+}
+
+// bytesStore is a store on a single contiguous byte slice.
+type bytesStore struct {
+	buf []byte
+	// shared reports whether buf is shared with a snapshot. buf is copied on
+	// the next write.
+	shared bool
+	// pool is the WriteSeekBufferPool that allocated buf, if any.
+	pool *WriteSeekBufferPool
+}
+
+func (s *bytesStore) readAt(p []byte, off int) error {
+	copy(p, s.buf[off:])
+	return nil
+}
+
+func (s *bytesStore) writeAt(p []byte, off int) error {
+	noff := off + len(p)
+	if s.shared {
+		buf := make([]byte, len(s.buf), cap(s.buf))
+		copy(buf, s.buf)
+		s.buf = buf
+		s.shared = false
+	}
+	if n := len(s.buf); noff > n {
+		if noff > cap(s.buf) {
+			c := 2 * cap(s.buf)
+			if c < noff {
+				c = noff
+			}
+			buf := make([]byte, noff, c)
+			copy(buf, s.buf)
+			s.buf = buf
+		} else {
+			s.buf = s.buf[:noff]
+			if off > n {
+				zero(s.buf[n:off])
+			}
+		}
+	}
+	copy(s.buf[off:noff], p)
+	return nil
+}
+
+func (s *bytesStore) truncate(n int) error {
+	if n > len(s.buf) {
+		return s.writeAt(nil, n)
+	}
+	s.buf = s.buf[:n]
+	return nil
+}
+
+func (s *bytesStore) bytes(n int) []byte {
+	return s.buf[:n]
+}
+
+func (s *bytesStore) snapshot(n int) store {
+	s.shared = true
+	return &bytesStore{buf: s.buf[:n:n], shared: true}
+}
+
+// pagedStore is a store on fixed-size pages that are allocated on the first
+// write. Pages that have never been written read back as zeros.
+type pagedStore struct {
+	size  int
+	pages map[int][]byte
+	// shared holds the indexes of the pages shared with snapshots. A shared
+	// page is copied on the next write to it.
+	shared map[int]bool
+}
+
+func newPagedStore(size int) *pagedStore {
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+	return &pagedStore{
+		size:  size,
+		pages: map[int][]byte{},
+	}
+}
+
+func (s *pagedStore) readAt(p []byte, off int) error {
+	for len(p) > 0 {
+		i, poff := off/s.size, off%s.size
+		var n int
+		if page, ok := s.pages[i]; ok {
+			n = copy(p, page[poff:])
+		} else {
+			n = s.size - poff
+			if n > len(p) {
+				n = len(p)
+			}
+			zero(p[:n])
+		}
+		p = p[n:]
+		off += n
+	}
+	return nil
+}
+
+func (s *pagedStore) writeAt(p []byte, off int) error {
+	for len(p) > 0 {
+		i, poff := off/s.size, off%s.size
+		n := copy(s.page(i)[poff:], p)
+		p = p[n:]
+		off += n
+	}
+	return nil
+}
+
+// page returns the writable page at the index i, allocating or copying it as needed.
+func (s *pagedStore) page(i int) []byte {
+	page, ok := s.pages[i]
+	if !ok {
+		page = make([]byte, s.size)
+		s.pages[i] = page
+	} else if s.shared[i] {
+		page = append([]byte{}, page...)
+		s.pages[i] = page
+		delete(s.shared, i)
+	}
+	return page
+}
+
+func (s *pagedStore) truncate(n int) error {
+	last := (n + s.size - 1) / s.size
+	for i := range s.pages {
+		if i >= last {
+			delete(s.pages, i)
+			delete(s.shared, i)
+		} else if i == n/s.size {
+			zero(s.page(i)[n%s.size:])
+		}
+	}
+	return nil
+}
+
+func (s *pagedStore) bytes(n int) []byte {
+	buf := make([]byte, n)
+	s.readAt(buf, 0)
+	return buf
+}
+
+func (s *pagedStore) snapshot(n int) store {
+	last := (n + s.size - 1) / s.size
+	snap := &pagedStore{
+		size:  s.size,
+		pages: map[int][]byte{},
+	}
+	if s.shared == nil {
+		s.shared = map[int]bool{}
+	}
+	for i, page := range s.pages {
+		if i < last {
+			snap.pages[i] = page
+			s.shared[i] = true
+		}
+	}
+	return snap
+}
+
+func zero(p []byte) {
+	for i := range p {
+		p[i] = 0
+	}
+}
@@ -0,0 +1,73 @@
+package io2
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestBytesStore_Grow(t *testing.T) {
+	b := NewWriteSeekBufferBytes(make([]byte, 6, 16))
+	copy(b.Bytes(), `123456`)
+	b.Truncate(2)
+	b.WriteAt([]byte(`x`), 4)
+
+	want := []byte{'1', '2', 0, 0, 'x'}
+	if got := b.Bytes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("bytes %v; want %v", got, want)
+	}
+}
+
+func TestPagedStore(t *testing.T) {
+	b := NewPagedWriteSeekBuffer(4)
+	defer b.Close()
+
+	b.Seek(1<<30, io.SeekStart)
+	b.Write([]byte(`end`))
+
+	s := b.s.(*pagedStore)
+	if len(s.pages) != 1 {
+		t.Errorf("allocated pages %d; want %d", len(s.pages), 1)
+	}
+	if b.Len() != 1<<30+3 {
+		t.Errorf("len %d; want %d", b.Len(), 1<<30+3)
+	}
+
+	p := make([]byte, 6)
+	n, err := b.ReadAt(p, 1<<30-3)
+	if err != nil {
+		t.Fatalf("read at: %v", err)
+	}
+	if want := []byte{0, 0, 0, 'e', 'n', 'd'}; !reflect.DeepEqual(p[:n], want) {
+		t.Errorf("read at %v; want %v", p[:n], want)
+	}
+}
+
+func TestPagedStore_Truncate(t *testing.T) {
+	b := NewPagedWriteSeekBuffer(4)
+	defer b.Close()
+
+	b.Write([]byte(`123456789`))
+	b.Truncate(5)
+	b.Seek(7, io.SeekStart)
+	b.Write([]byte(`x`))
+
+	want := []byte{'1', '2', '3', '4', '5', 0, 0, 'x'}
+	if got := b.Bytes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("bytes %v; want %v", got, want)
+	}
+	if got := len(b.s.(*pagedStore).pages); got != 2 {
+		t.Errorf("pages %d; want %d", got, 2)
+	}
+
+	var buf bytes.Buffer
+	b.Seek(1, io.SeekStart)
+	n, err := b.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("write to: %v", err)
+	}
+	if n != 7 || !reflect.DeepEqual(buf.Bytes(), want[1:]) {
+		t.Errorf("write to %d %v; want %d %v", n, buf.Bytes(), 7, want[1:])
+	}
+}
@@ -0,0 +1,89 @@
+package io2
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// WriterFile is a file that can be written.
+type WriterFile interface {
+	fs.File
+	io.Writer
+}
+
+// WriteFS is the interface implemented by a file system that can create and write files.
+type WriteFS interface {
+	fs.FS
+	// OpenFile opens the named file with the flag (os.O_RDONLY, os.O_CREATE etc.) and perm.
+	OpenFile(name string, flag int, perm fs.FileMode) (WriterFile, error)
+}
+
+// MkdirFS is the interface implemented by a file system that can create directories.
+type MkdirFS interface {
+	fs.FS
+	// Mkdir creates a new directory with the specified name and permission bits.
+	Mkdir(name string, perm fs.FileMode) error
+	// MkdirAll creates a directory named path, along with any necessary parents.
+	MkdirAll(name string, perm fs.FileMode) error
+}
+
+// RemoveFS is the interface implemented by a file system that can remove files.
+type RemoveFS interface {
+	fs.FS
+	// Remove removes the named file or empty directory.
+	Remove(name string) error
+	// RemoveAll removes name and any children it contains.
+	RemoveAll(name string) error
+}
+
+// RenameFS is the interface implemented by a file system that can rename files.
+type RenameFS interface {
+	fs.FS
+	// Rename renames (moves) oldname to newname.
+	Rename(oldname, newname string) error
+}
+
+// ChmodFS is the interface implemented by a file system that can change the file modes.
+type ChmodFS interface {
+	fs.FS
+	// Chmod changes the mode of the named file to mode.
+	Chmod(name string, mode fs.FileMode) error
+}
+
+// ChtimesFS is the interface implemented by a file system that can change the file times.
+type ChtimesFS interface {
+	fs.FS
+	// Chtimes changes the access and modification times of the named file.
+	Chtimes(name string, atime, mtime time.Time) error
+}
+
+// WritableFS is the interface that groups the WriteFS, MkdirFS, RemoveFS,
+// RenameFS, ChmodFS and ChtimesFS.
+type WritableFS interface {
+	WriteFS
+	MkdirFS
+	RemoveFS
+	RenameFS
+	ChmodFS
+	ChtimesFS
+}
+
+// Create creates or truncates the named file in fsys.
+func Create(fsys WriteFS, name string) (WriterFile, error) {
+	return fsys.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+}
+
+// WriteFile writes data to the named file in fsys, creating it if necessary.
+func WriteFile(fsys WriteFS, name string, data []byte, perm fs.FileMode) error {
+	f, err := fsys.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if err1 := f.Close(); err1 != nil && err == nil {
+		err = err1
+	}
+	return err
+}
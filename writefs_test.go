@@ -0,0 +1,244 @@
+package io2
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func testWritableFS(t *testing.T, fsys WritableFS) {
+	if err := WriteFile(fsys, "a.txt", []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := fsys.MkdirAll("dir/sub", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := fsys.MkdirAll("dir/sub", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(fsys, "dir/sub/b.txt", []byte("b"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := fsys.Mkdir("dir/empty", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := fstest.TestFS(fsys, "a.txt", "dir/sub/b.txt", "dir/empty"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Errors", func(t *testing.T) {
+		tests := []struct {
+			name string
+			fn   func() error
+			err  error
+		}{
+			{
+				name: "Open not exist",
+				fn: func() error {
+					_, err := fsys.Open("none.txt")
+					return err
+				},
+				err: fs.ErrNotExist,
+			}, {
+				name: "OpenFile O_EXCL",
+				fn: func() error {
+					_, err := fsys.OpenFile("a.txt", os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644)
+					return err
+				},
+				err: fs.ErrExist,
+			}, {
+				name: "OpenFile without parent",
+				fn: func() error {
+					_, err := fsys.OpenFile("none/a.txt", os.O_RDWR|os.O_CREATE, 0644)
+					return err
+				},
+				err: fs.ErrNotExist,
+			}, {
+				name: "Mkdir exist",
+				fn: func() error {
+					return fsys.Mkdir("dir", 0755)
+				},
+				err: fs.ErrExist,
+			}, {
+				name: "Mkdir without parent",
+				fn: func() error {
+					return fsys.Mkdir("none/dir", 0755)
+				},
+				err: fs.ErrNotExist,
+			}, {
+				name: "Remove not exist",
+				fn: func() error {
+					return fsys.Remove("none.txt")
+				},
+				err: fs.ErrNotExist,
+			}, {
+				name: "Rename not exist",
+				fn: func() error {
+					return fsys.Rename("none.txt", "a.txt")
+				},
+				err: fs.ErrNotExist,
+			}, {
+				name: "Chmod not exist",
+				fn: func() error {
+					return fsys.Chmod("none.txt", 0644)
+				},
+				err: fs.ErrNotExist,
+			}, {
+				name: "Invalid path",
+				fn: func() error {
+					_, err := fsys.OpenFile("../a.txt", os.O_RDONLY, 0)
+					return err
+				},
+				err: fs.ErrInvalid,
+			},
+		}
+		for _, test := range tests {
+			if err := test.fn(); !errors.Is(err, test.err) {
+				t.Errorf("%s: error %v; want %v", test.name, err, test.err)
+			}
+		}
+		if err := fsys.Remove("dir/sub"); err == nil {
+			t.Errorf("Remove of a non-empty directory returns no error")
+		}
+	})
+
+	t.Run("ReadWrite", func(t *testing.T) {
+		f, err := Create(fsys, "rw.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if _, err := f.Write([]byte("hello world")); err != nil {
+			t.Fatal(err)
+		}
+		s := f.(io.Seeker)
+		if _, err := s.Seek(6, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte("WORLD")); err != nil {
+			t.Fatal(err)
+		}
+		p := make([]byte, 5)
+		if _, err := f.(io.ReaderAt).ReadAt(p, 0); err != nil {
+			t.Fatal(err)
+		}
+		if string(p) != "hello" {
+			t.Errorf("ReadAt %s; want %s", p, "hello")
+		}
+
+		a, err := fsys.OpenFile("rw.txt", os.O_WRONLY|os.O_APPEND, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := a.Write([]byte("!")); err != nil {
+			t.Fatal(err)
+		}
+		if err := a.Close(); err != nil {
+			t.Fatal(err)
+		}
+		got, err := fs.ReadFile(fsys, "rw.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != "hello WORLD!" {
+			t.Errorf("ReadFile %s; want %s", got, "hello WORLD!")
+		}
+	})
+
+	t.Run("RenameRemove", func(t *testing.T) {
+		if err := WriteFile(fsys, "old.txt", []byte("old"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := fsys.Rename("old.txt", "dir/new.txt"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fs.Stat(fsys, "old.txt"); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("old.txt exists: %v", err)
+		}
+		got, err := fs.ReadFile(fsys, "dir/new.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != "old" {
+			t.Errorf("ReadFile %s; want %s", got, "old")
+		}
+		if err := fsys.Remove("dir/new.txt"); err != nil {
+			t.Fatal(err)
+		}
+		if err := fsys.RemoveAll("dir"); err != nil {
+			t.Fatal(err)
+		}
+		if err := fsys.RemoveAll("dir"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fs.Stat(fsys, "dir/sub/b.txt"); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("dir/sub/b.txt exists: %v", err)
+		}
+	})
+
+	t.Run("ChmodChtimes", func(t *testing.T) {
+		mtime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		if err := fsys.Chmod("a.txt", 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := fsys.Chtimes("a.txt", mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+		info, err := fs.Stat(fsys, "a.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode() != 0600 {
+			t.Errorf("mode %v; want %v", info.Mode(), fs.FileMode(0600))
+		}
+		if !info.ModTime().Equal(mtime) {
+			t.Errorf("mtime %v; want %v", info.ModTime(), mtime)
+		}
+	})
+}
+
+func TestOSFS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "*.osfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	testWritableFS(t, NewOSFS(dir))
+}
+
+func TestOSFS_Errors(t *testing.T) {
+	fsys := NewOSFS(t.TempDir())
+
+	f, err := fsys.Open("missing.txt")
+	if f != nil {
+		t.Errorf("open returns non-nil %#v", f)
+	}
+	var perr *fs.PathError
+	if !errors.As(err, &perr) || !errors.Is(err, fs.ErrNotExist) || perr.Path != "missing.txt" {
+		t.Errorf("open error %#v; want a PathError of %s", err, "missing.txt")
+	}
+	wf, err := fsys.OpenFile("dir/missing.txt", os.O_RDWR|os.O_CREATE, 0644)
+	if wf != nil {
+		t.Errorf("openfile returns non-nil %#v", wf)
+	}
+	if !errors.As(err, &perr) || perr.Path != "dir/missing.txt" {
+		t.Errorf("openfile error %#v; want a PathError of %s", err, "dir/missing.txt")
+	}
+	if _, err := fsys.Stat("missing.txt"); !errors.As(err, &perr) || perr.Path != "missing.txt" {
+		t.Errorf("stat error %#v; want a PathError of %s", err, "missing.txt")
+	}
+	var lerr *os.LinkError
+	if err := fsys.Rename("a", "b"); !errors.As(err, &lerr) || lerr.Old != "a" || lerr.New != "b" {
+		t.Errorf("rename error %#v; want a LinkError of %s %s", err, "a", "b")
+	}
+}
+
+func TestMemFS(t *testing.T) {
+	testWritableFS(t, NewMemFS())
+}
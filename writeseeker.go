@@ -1,7 +1,7 @@
 package io2
 
 import (
-	"bytes"
+	"errors"
 	"io"
 )
 
@@ -12,19 +12,32 @@ type WriteSeekCloser interface {
 	io.Closer
 }
 
-// WriteSeekBuffer implements io.WriteSeeker that using in-memory byte buffer.
+// WriteSeekBuffer implements io.ReadWriteSeeker, io.ReaderAt, io.WriterAt,
+// io.ReaderFrom, io.WriterTo and io.Closer that using in-memory byte buffer.
+// Read, Write and Seek share one offset like *os.File.
 type WriteSeekBuffer struct {
-	buf *bytes.Buffer
-	off int
-	len int
+	s     store
+	off   int
+	len   int
+	dirty dirtyState
+	// data is the sorted extents that have been written. The rest are holes.
+	data []Extent
+	max  int
 }
 
-var _ WriteSeekCloser = (*WriteSeekBuffer)(nil)
+var (
+	_ WriteSeekCloser    = (*WriteSeekBuffer)(nil)
+	_ io.ReadWriteSeeker = (*WriteSeekBuffer)(nil)
+	_ io.ReaderAt        = (*WriteSeekBuffer)(nil)
+	_ io.WriterAt        = (*WriteSeekBuffer)(nil)
+	_ io.ReaderFrom      = (*WriteSeekBuffer)(nil)
+	_ io.WriterTo        = (*WriteSeekBuffer)(nil)
+)
 
 // NewWriteSeekBuffer returns an WriteSeekBuffer with the initial capacity.
 func NewWriteSeekBuffer(capacity int) *WriteSeekBuffer {
 	return &WriteSeekBuffer{
-		buf: bytes.NewBuffer(make([]byte, capacity)),
+		s: &bytesStore{buf: make([]byte, 0, capacity)},
 	}
 }
 
@@ -32,36 +45,189 @@ func NewWriteSeekBuffer(capacity int) *WriteSeekBuffer {
 func NewWriteSeekBufferBytes(buf []byte) *WriteSeekBuffer {
 	off := len(buf)
 	return &WriteSeekBuffer{
-		buf: bytes.NewBuffer(buf),
-		off: off,
-		len: off,
+		s:    &bytesStore{buf: buf},
+		off:  off,
+		len:  off,
+		data: addExtent(nil, Extent{Len: off}),
 	}
 }
 
-// Write appends the contents of p to the buffer, growing the buffer as needed.
-// The return value n is the length of p; err is always nil.
-func (b *WriteSeekBuffer) Write(p []byte) (int, error) {
-	n := len(p)
-	noff := b.off + n
+// NewPagedWriteSeekBuffer returns an WriteSeekBuffer that stores its contents in
+// fixed-size pages. Pages are allocated on the first write to them, so seeking
+// far past the end does not allocate the gap, and unwritten regions read back
+// as zeros. If pageSize is not positive, DefaultPageSize is used.
+func NewPagedWriteSeekBuffer(pageSize int) *WriteSeekBuffer {
+	return &WriteSeekBuffer{
+		s: newPagedStore(pageSize),
+	}
+}
 
-	if grow := noff - b.buf.Len(); grow > 0 {
-		b.buf.Write(make([]byte, grow))
+// SetMaxSize sets the maximum size of the buffer. Writes past it are cut at the
+// maximum size and return ErrTooLarge, and Seek past it returns ErrTooLarge
+// without moving the offset. If n is not positive, the size is unlimited.
+func (b *WriteSeekBuffer) SetMaxSize(n int) {
+	if n < 0 {
+		n = 0
 	}
+	b.max = n
+}
 
-	copy(b.buf.Bytes()[b.off:noff], p)
+// MaxSize returns the maximum size of the buffer, or 0 if it is unlimited.
+func (b *WriteSeekBuffer) MaxSize() int {
+	return b.max
+}
 
-	b.off = noff
-	if noff > b.len {
+func (b *WriteSeekBuffer) writeAt(p []byte, off int) (int, error) {
+	var tooLarge error
+	if b.max > 0 && off+len(p) > b.max {
+		rest := b.max - off
+		if rest < 0 {
+			rest = 0
+		}
+		p = p[:rest]
+		tooLarge = ErrTooLarge
+	}
+	if len(p) == 0 {
+		return 0, tooLarge
+	}
+	if err := b.s.writeAt(p, off); err != nil {
+		return 0, err
+	}
+	b.dirty.write(off, len(p))
+	b.data = addExtent(b.data, Extent{Off: off, Len: len(p)})
+	if noff := off + len(p); noff > b.len {
 		b.len = noff
 	}
+	return len(p), tooLarge
+}
+
+// Write writes the contents of p to the offset, growing the buffer as needed.
+// The return value n is the length of p unless the buffer reaches the maximum size.
+func (b *WriteSeekBuffer) Write(p []byte) (int, error) {
+	n, err := b.writeAt(p, b.off)
+	b.off += n
+	return n, err
+}
+
+// WriteAt writes the contents of p to the offset off, growing the buffer as needed.
+// WriteAt does not change the offset.
+func (b *WriteSeekBuffer) WriteAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+	return b.writeAt(p, int(off))
+}
+
+// ReadFrom reads data from r until EOF and writes it to the offset.
+// The return value n is the number of bytes read.
+func (b *WriteSeekBuffer) ReadFrom(r io.Reader) (int64, error) {
+	var total int64
+	p := make([]byte, 32*1024)
+	for {
+		n, err := r.Read(p)
+		if n > 0 {
+			m, werr := b.Write(p[:n])
+			total += int64(m)
+			if werr != nil {
+				return total, werr
+			}
+		}
+		if err == io.EOF {
+			return total, nil
+		}
+		if err != nil {
+			return total, err
+		}
+	}
+}
+
+func (b *WriteSeekBuffer) readAt(p []byte, off int) (int, error) {
+	if off >= b.len {
+		return 0, io.EOF
+	}
+	n := len(p)
+	if rest := b.len - off; n > rest {
+		n = rest
+	}
+	if err := b.s.readAt(p[:n], off); err != nil {
+		return 0, err
+	}
 	return n, nil
 }
 
-// Seek sets the offset for the next Write to offset, interpreted according to whence:
-//   SeekStart means relative to the start of the file,
-//   SeekCurrent means relative to the current offset,
-//   SeekEnd means relative to the end.
+// Read reads the next len(p) bytes from the offset or until the buffer is drained.
+// If the offset is at the end of the buffer, Read returns 0, io.EOF.
+func (b *WriteSeekBuffer) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	n, err := b.readAt(p, b.off)
+	b.off += n
+	return n, err
+}
+
+// ReadAt reads len(p) bytes from the offset off. ReadAt does not change the offset.
+// If ReadAt reads fewer than len(p) bytes, it returns io.EOF.
+func (b *WriteSeekBuffer) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+	n, err := b.readAt(p, int(off))
+	if err == nil && n < len(p) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// WriteTo writes data from the offset to w until the buffer is drained.
+// The return value n is the number of bytes written. The holes are written as
+// zeros; use WriteSparseTo to preserve them.
+func (b *WriteSeekBuffer) WriteTo(w io.Writer) (int64, error) {
+	if b.off >= b.len {
+		return 0, nil
+	}
+	return b.writeData(w, b.len)
+}
+
+// writeData writes the bytes from the offset to end to w.
+func (b *WriteSeekBuffer) writeData(w io.Writer, end int) (int64, error) {
+	if bs, ok := b.s.(*bytesStore); ok {
+		return b.writeTo(w, bs.buf[b.off:end])
+	}
+	var total int64
+	p := make([]byte, 32*1024)
+	for b.off < end {
+		n := end - b.off
+		if n > len(p) {
+			n = len(p)
+		}
+		if err := b.s.readAt(p[:n], b.off); err != nil {
+			return total, err
+		}
+		m, err := b.writeTo(w, p[:n])
+		total += m
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+func (b *WriteSeekBuffer) writeTo(w io.Writer, p []byte) (int64, error) {
+	n, err := w.Write(p)
+	b.off += n
+	if err == nil && n < len(p) {
+		err = io.ErrShortWrite
+	}
+	return int64(n), err
+}
+
+// Seek sets the offset for the next Read or Write to offset, interpreted according to whence:
+// SeekStart means relative to the start of the file, SeekCurrent means relative to the
+// current offset, and SeekEnd means relative to the end.
+// SeekData and SeekHole seek to the next data or hole at or after offset.
 // Seek returns the new offset relative to the start of the file and an error, if any.
+// Unlike *os.File, seeking to an offset before the start of the file sets the offset to 0.
 func (b *WriteSeekBuffer) Seek(offset int64, whence int) (int64, error) {
 	off := int(offset)
 	noff := 0
@@ -71,18 +237,29 @@ func (b *WriteSeekBuffer) Seek(offset int64, whence int) (int64, error) {
 	case io.SeekCurrent:
 		noff = b.off + off
 	case io.SeekEnd:
-		noff = b.buf.Len() + off
+		noff = b.len + off
+	case SeekData, SeekHole:
+		return b.seekSparse(offset, whence)
 	}
 	if noff < 0 {
 		noff = 0
 	}
+	if b.max > 0 && noff > b.max {
+		return 0, ErrTooLarge
+	}
 	b.off = noff
 	return int64(noff), nil
 }
 
-// Close calls b.Truncate(0).
+// Close calls b.Truncate(0) and releases the resources of the backing store
+// such as a temp file.
 func (b *WriteSeekBuffer) Close() error {
 	b.Truncate(0)
+	b.dirty.reset()
+	b.data = nil
+	if c, ok := b.s.(storeCloser); ok {
+		return c.close()
+	}
 	return nil
 }
 
@@ -96,16 +273,28 @@ func (b *WriteSeekBuffer) Len() int {
 	return b.len
 }
 
-// Bytes returns a slice of length b.Len() of the buffer.
+// Bytes returns a slice of length b.Len() of the buffer. If the buffer is
+// paged, Bytes flattens the pages into a new slice.
 func (b *WriteSeekBuffer) Bytes() []byte {
-	n := b.buf.Len()
-	if n > b.len {
-		n = b.len
+	return b.s.bytes(b.len)
+}
+
+// Snapshot returns a read-only view of the current contents. Later changes to
+// the buffer do not show up in the view. The view shares the contents with the
+// buffer until they are modified; a paged buffer copies only the modified pages.
+// If the buffer has spilled to a temp file, Snapshot copies the contents into memory.
+func (b *WriteSeekBuffer) Snapshot() *io.SectionReader {
+	snap := &WriteSeekBuffer{
+		s:   b.s.snapshot(b.len),
+		len: b.len,
 	}
-	return b.buf.Bytes()[:n]
+	return io.NewSectionReader(snap, 0, int64(b.len))
 }
 
-// Truncate changes the size of the buffer with offset.
+// Truncate changes the size of the buffer with offset. If n is negative, it is
+// relative to the offset. Like *os.File, if n is greater than the length, the
+// buffer is extended with a hole that reads back as zeros; the extension is cut
+// at the maximum size.
 func (b *WriteSeekBuffer) Truncate(n int) {
 	if n < 0 {
 		n = b.off + n
@@ -113,9 +302,24 @@ func (b *WriteSeekBuffer) Truncate(n int) {
 	if n < 0 {
 		n = 0
 	}
-	if n < b.buf.Len() {
-		b.buf.Truncate(n)
+	if b.max > 0 && n > b.max && n > b.len {
+		n = b.max
+		if n < b.len {
+			n = b.len
+		}
+	}
+	if n < b.len {
+		b.s.truncate(n)
+		b.len = n
+		b.dirty.truncate(n)
+		b.data = clipExtents(b.data, n)
+	} else if n > b.len {
+		if err := b.s.truncate(n); err != nil {
+			n = b.len
+		} else {
+			b.dirty.write(b.len, n-b.len)
+			b.len = n
+		}
 	}
 	b.off = n
-	b.len = n
 }
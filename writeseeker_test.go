@@ -1,9 +1,15 @@
 package io2
 
 import (
+	"bytes"
+	"errors"
 	"io"
+	"io/ioutil"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/jarxorg/io2/io2test"
 )
 
 func TestWrite(t *testing.T) {
@@ -154,7 +160,7 @@ func TestTruncate(t *testing.T) {
 			want: []byte(`123`),
 		}, {
 			n:    10,
-			want: []byte(`123`),
+			want: []byte("123\x00\x00\x00\x00\x00\x00\x00"),
 		}, {
 			n:    -100,
 			want: []byte{},
@@ -167,5 +173,297 @@ func TestTruncate(t *testing.T) {
 		if !reflect.DeepEqual(got, test.want) {
 			t.Errorf("tests[%d] truncate bytes %v; want %v", i, got, test.want)
 		}
+		if b.Len() != len(test.want) || b.Offset() != len(test.want) {
+			t.Errorf("tests[%d] len %d off %d; want %d", i, b.Len(), b.Offset(), len(test.want))
+		}
+	}
+}
+
+func TestTruncate_Extend(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		b   *WriteSeekBuffer
+		max int
+	}{
+		{b: NewWriteSeekBuffer(0)},
+		{b: NewPagedWriteSeekBuffer(4)},
+		{b: NewSpillWriteSeekBuffer(4, dir)},
+		{b: NewSpillWriteSeekBuffer(8, dir)},
+		{b: NewWriteSeekBuffer(0), max: 8},
+	}
+	for i, test := range tests {
+		b := test.b
+		defer b.Close()
+		b.SetMaxSize(test.max)
+		b.Write([]byte(`123456`))
+		b.Truncate(2)
+		b.Truncate(10)
+
+		want := "12\x00\x00\x00\x00\x00\x00\x00\x00"
+		if test.max > 0 {
+			want = want[:test.max]
+		}
+		if got := string(b.Bytes()); got != want {
+			t.Errorf("tests[%d] bytes %q; want %q", i, got, want)
+		}
+		if b.Len() != len(want) || b.Offset() != len(want) {
+			t.Errorf("tests[%d] len %d off %d; want %d", i, b.Len(), b.Offset(), len(want))
+		}
+		got, err := ioutil.ReadAll(io.NewSectionReader(b, 0, 100))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("tests[%d] read %q; want %q", i, got, want)
+		}
+		if got, want := b.DataExtents(), []Extent{{Len: 2}}; !reflect.DeepEqual(got, want) {
+			t.Errorf("tests[%d] data extents %v; want %v", i, got, want)
+		}
+		if got, want := b.DirtyExtents(), []Extent{{Len: len(want)}}; !reflect.DeepEqual(got, want) {
+			t.Errorf("tests[%d] dirty extents %v; want %v", i, got, want)
+		}
+	}
+}
+
+func TestWriteSeekBuffer_Contract(t *testing.T) {
+	content := []byte("Hello, World!")
+	io2test.TestWriter(t, func(t *testing.T) io.Writer {
+		return NewWriteSeekBuffer(4)
+	}, func(t *testing.T, w io.Writer) []byte {
+		return w.(*WriteSeekBuffer).Bytes()
+	})
+	io2test.TestClampingSeeker(t, func(t *testing.T) io.Seeker {
+		b := NewWriteSeekBuffer(32)
+		b.Write(content)
+		b.Seek(0, io.SeekStart)
+		return b
+	}, content)
+	io2test.TestReader(t, func(t *testing.T) io.Reader {
+		b := NewWriteSeekBufferBytes(append([]byte{}, content...))
+		b.Seek(0, io.SeekStart)
+		return b
+	}, content)
+	io2test.TestReaderAt(t, func(t *testing.T) io.ReaderAt {
+		return NewWriteSeekBufferBytes(append([]byte{}, content...))
+	}, content)
+	io2test.TestWriterAt(t, func(t *testing.T) io.WriterAt {
+		return NewWriteSeekBuffer(0)
+	}, func(t *testing.T, w io.WriterAt) []byte {
+		return w.(*WriteSeekBuffer).Bytes()
+	})
+	io2test.TestCloser(t, func(t *testing.T) io.Closer {
+		return NewWriteSeekBuffer(0)
+	}, true)
+}
+
+func TestReadWrite(t *testing.T) {
+	b := NewWriteSeekBuffer(0)
+	defer b.Close()
+
+	b.Write([]byte(`123456789`))
+	p := make([]byte, 3)
+	if _, err := b.Read(p); err != io.EOF {
+		t.Fatalf("read at end error %v; want %v", err, io.EOF)
+	}
+
+	b.Seek(3, io.SeekStart)
+	n, err := b.Read(p)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if got := string(p[:n]); got != "456" {
+		t.Errorf("read %s; want %s", got, "456")
+	}
+	b.Write([]byte(`def`))
+	if got := string(b.Bytes()); got != "123456def" {
+		t.Errorf("bytes %s; want %s", got, "123456def")
+	}
+	if b.Offset() != 9 {
+		t.Errorf("off %d; want %d", b.Offset(), 9)
+	}
+}
+
+func TestReadAtWriteAt(t *testing.T) {
+	b := NewWriteSeekBufferBytes([]byte(`123`))
+	defer b.Close()
+
+	if _, err := b.WriteAt([]byte(`xy`), 5); err != nil {
+		t.Fatalf("write at: %v", err)
+	}
+	if got, want := b.Bytes(), []byte{'1', '2', '3', 0, 0, 'x', 'y'}; !reflect.DeepEqual(got, want) {
+		t.Errorf("bytes %v; want %v", got, want)
+	}
+	if b.Offset() != 3 {
+		t.Errorf("off %d; want %d", b.Offset(), 3)
+	}
+
+	p := make([]byte, 4)
+	n, err := b.ReadAt(p, 4)
+	if err != io.EOF {
+		t.Errorf("read at error %v; want %v", err, io.EOF)
+	}
+	if got, want := p[:n], []byte{0, 'x', 'y'}; !reflect.DeepEqual(got, want) {
+		t.Errorf("read at %v; want %v", got, want)
+	}
+	if _, err := b.ReadAt(p, -1); err == nil {
+		t.Errorf("read at negative offset: no error")
+	}
+	if _, err := b.WriteAt(p, -1); err == nil {
+		t.Errorf("write at negative offset: no error")
+	}
+}
+
+func TestReadFromWriteTo(t *testing.T) {
+	b := NewWriteSeekBufferBytes([]byte(`123`))
+	defer b.Close()
+
+	b.Seek(1, io.SeekStart)
+	n, err := b.ReadFrom(strings.NewReader(`abcd`))
+	if err != nil {
+		t.Fatalf("read from: %v", err)
+	}
+	if n != 4 {
+		t.Errorf("read from %d; want %d", n, 4)
+	}
+	if got := string(b.Bytes()); got != "1abcd" {
+		t.Errorf("bytes %s; want %s", got, "1abcd")
+	}
+
+	b.Seek(2, io.SeekStart)
+	var buf bytes.Buffer
+	n, err = b.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("write to: %v", err)
+	}
+	if n != 3 || buf.String() != "bcd" {
+		t.Errorf("write to %d %s; want %d %s", n, buf.String(), 3, "bcd")
+	}
+	if b.Offset() != 5 {
+		t.Errorf("off %d; want %d", b.Offset(), 5)
+	}
+
+	wantErr := errors.New("test")
+	b.Seek(0, io.SeekStart)
+	if _, err := b.ReadFrom(io.MultiReader(strings.NewReader(`x`), errReader{wantErr})); err != wantErr {
+		t.Errorf("read from error %v; want %v", err, wantErr)
+	}
+}
+
+type errReader struct{ err error }
+
+func (r errReader) Read(p []byte) (int, error) { return 0, r.err }
+
+func TestPagedWriteSeekBuffer_Contract(t *testing.T) {
+	content := []byte("Hello, World!")
+	io2test.TestWriter(t, func(t *testing.T) io.Writer {
+		return NewPagedWriteSeekBuffer(4)
+	}, func(t *testing.T, w io.Writer) []byte {
+		return w.(*WriteSeekBuffer).Bytes()
+	})
+	io2test.TestWriterAt(t, func(t *testing.T) io.WriterAt {
+		return NewPagedWriteSeekBuffer(4)
+	}, func(t *testing.T, w io.WriterAt) []byte {
+		return w.(*WriteSeekBuffer).Bytes()
+	})
+	newBuffer := func() *WriteSeekBuffer {
+		b := NewPagedWriteSeekBuffer(4)
+		b.Write(content)
+		b.Seek(0, io.SeekStart)
+		return b
+	}
+	io2test.TestClampingSeeker(t, func(t *testing.T) io.Seeker { return newBuffer() }, content)
+	io2test.TestReader(t, func(t *testing.T) io.Reader { return newBuffer() }, content)
+	io2test.TestReaderAt(t, func(t *testing.T) io.ReaderAt { return newBuffer() }, content)
+}
+
+func TestSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	tests := []*WriteSeekBuffer{
+		NewWriteSeekBuffer(0),
+		NewWriteSeekBuffer(64),
+		NewPagedWriteSeekBuffer(4),
+		NewSpillWriteSeekBuffer(64, dir),
+		NewSpillWriteSeekBuffer(4, dir),
+	}
+	for i, b := range tests {
+		b.Write([]byte(`123456789`))
+		snap := b.Snapshot()
+
+		b.Seek(2, io.SeekStart)
+		b.Write([]byte(`ab`))
+		b.Truncate(6)
+		b.Write([]byte(`cd`))
+
+		got, err := ioutil.ReadAll(snap)
+		if err != nil {
+			t.Fatalf("tests[%d] read: %v", i, err)
+		}
+		if want := "123456789"; string(got) != want {
+			t.Errorf("tests[%d] snapshot %s; want %s", i, got, want)
+		}
+		if want := "12ab56cd"; string(b.Bytes()) != want {
+			t.Errorf("tests[%d] bytes %s; want %s", i, b.Bytes(), want)
+		}
+		b.Close()
+	}
+}
+
+func TestSnapshot_SharePages(t *testing.T) {
+	b := NewPagedWriteSeekBuffer(4)
+	defer b.Close()
+
+	b.Write([]byte(`123456789`))
+	b.Snapshot()
+	b.WriteAt([]byte(`x`), 4)
+
+	s := b.s.(*pagedStore)
+	if !s.shared[0] || s.shared[1] || !s.shared[2] {
+		t.Errorf("shared %v; want only pages 0 and 2", s.shared)
+	}
+}
+
+func TestMaxSize(t *testing.T) {
+	b := NewPagedWriteSeekBuffer(4)
+	defer b.Close()
+	b.SetMaxSize(8)
+
+	if b.MaxSize() != 8 {
+		t.Errorf("max size %d; want %d", b.MaxSize(), 8)
+	}
+	if _, err := b.Write([]byte(`12345`)); err != nil {
+		t.Fatal(err)
+	}
+	n, err := b.Write([]byte(`6789`))
+	if !errors.Is(err, ErrTooLarge) {
+		t.Errorf("write error %v; want %v", err, ErrTooLarge)
+	}
+	if n != 3 || string(b.Bytes()) != "12345678" || b.Offset() != 8 {
+		t.Errorf("write %d %s off %d; want %d %s off %d", n, b.Bytes(), b.Offset(), 3, "12345678", 8)
+	}
+	if n, err := b.WriteAt([]byte(`x`), 1<<40); n != 0 || !errors.Is(err, ErrTooLarge) {
+		t.Errorf("write at %d %v; want %d %v", n, err, 0, ErrTooLarge)
+	}
+	if _, err := b.Seek(9, io.SeekStart); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("seek error %v; want %v", err, ErrTooLarge)
+	}
+	if b.Offset() != 8 {
+		t.Errorf("off %d; want %d", b.Offset(), 8)
+	}
+
+	b.Seek(0, io.SeekStart)
+	m, err := b.ReadFrom(strings.NewReader(`abcdefghij`))
+	if !errors.Is(err, ErrTooLarge) {
+		t.Errorf("read from error %v; want %v", err, ErrTooLarge)
+	}
+	if m != 8 || string(b.Bytes()) != "abcdefgh" {
+		t.Errorf("read from %d %s; want %d %s", m, b.Bytes(), 8, "abcdefgh")
+	}
+	if len(b.s.(*pagedStore).pages) != 2 {
+		t.Errorf("pages %d; want %d", len(b.s.(*pagedStore).pages), 2)
+	}
+
+	b.SetMaxSize(0)
+	if _, err := b.WriteAt([]byte(`x`), 100); err != nil {
+		t.Errorf("write at unlimited: %v", err)
 	}
 }